@@ -2,9 +2,6 @@ package solidserver
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
@@ -19,8 +16,9 @@ func dataSourceip6address() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space of the IPv6 address.",
-				Required:    true,
+				Description: "The name of the space of the IPv6 address (Default: search across all the spaces).",
+				Optional:    true,
+				Computed:    true,
 			},
 			"subnet": {
 				Type:        schema.TypeString,
@@ -33,9 +31,11 @@ func dataSourceip6address() *schema.Resource {
 				Computed:    true,
 			},
 			"address": {
-				Type:        schema.TypeString,
-				Description: "The IPv6 address.",
-				Required:    true,
+				Type:         schema.TypeString,
+				Description:  "The IPv6 address to look up.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"address", "name", "mac", "query"},
 			},
 			"device": {
 				Type:        schema.TypeString,
@@ -44,14 +44,21 @@ func dataSourceip6address() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "The short name or FQDN of the IPv6 address.",
+				Description: "The short name or FQDN of the IPv6 address to look up.",
+				Optional:    true,
 				Computed:    true,
 			},
 			"mac": {
 				Type:        schema.TypeString,
-				Description: "The MAC Address of the IPv6 address.",
+				Description: "The MAC Address of the IPv6 address to look up.",
+				Optional:    true,
 				Computed:    true,
 			},
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to look up the IPv6 address (ex: \"ip6_class_parameters LIKE '%owner=team1%'\").",
+				Optional:    true,
+			},
 			"prefix": {
 				Type:        schema.TypeString,
 				Description: "The IPv6 address prefix.",
@@ -77,72 +84,46 @@ func dataSourceip6address() *schema.Resource {
 }
 
 func dataSourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
-
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+d.Get("space").(string)+"' AND ip6_addr='"+ip6tohexip6(d.Get("address").(string))+"'")
-
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_address6_list", &parameters)
-
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
-
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.SetId(buf[0]["ip6_id"].(string))
-			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("subnet", buf[0]["subnet6_name"].(string))
-			d.Set("pool", buf[0]["pool6_name"].(string))
-			d.Set("name", buf[0]["ip6_name"].(string))
-			d.Set("device", buf[0]["hostdev_name"].(string))
-
-			prefix_size, _ := strconv.Atoi(buf[0]["subnet6_prefix"].(string))
+	// Looking up the IPv6 address by address, name, MAC address or query
+	entry, err := ipaddresslookup(ipaddresslookupclause(d, true), true, meta)
 
-			d.Set("prefix", hexip6toip6(buf[0]["subnet6_start_ip6_addr"].(string))+"/"+buf[0]["subnet6_prefix"].(string))
-			d.Set("prefix_size", prefix_size)
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["ip6_mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["ip6_mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
-			}
+	d.SetId(entry["ip6_id"].(string))
+	d.Set("space", entry["site_name"].(string))
+	d.Set("address", hexip6toip6(entry["ip6_addr"].(string)))
+	d.Set("subnet", entry["subnet6_name"].(string))
+	d.Set("pool", entry["pool6_name"].(string))
+	d.Set("name", entry["ip6_name"].(string))
+	d.Set("device", entry["hostdev_name"].(string))
 
-			d.Set("class", buf[0]["ip6_class_name"].(string))
+	prefix_size, _ := strconv.Atoi(entry["subnet6_prefix"].(string))
 
-			// Updating local class_parameters
-			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip6_class_parameters"].(string))
-			computedClassParameters := map[string]string{}
+	d.Set("prefix", hexip6toip6(entry["subnet6_start_ip6_addr"].(string))+"/"+entry["subnet6_prefix"].(string))
+	d.Set("prefix_size", prefix_size)
 
-			for ck := range retrievedClassParameters {
-				if ck != "gateway" {
-					computedClassParameters[ck] = retrievedClassParameters[ck][0]
-				}
-			}
+	if macIgnore, _ := regexp.MatchString("^EIP:", entry["ip6_mac_addr"].(string)); !macIgnore {
+		d.Set("mac", entry["ip6_mac_addr"].(string))
+	} else {
+		d.Set("mac", "")
+	}
 
-			d.Set("class_parameters", computedClassParameters)
+	d.Set("class", entry["ip6_class_name"].(string))
 
-			return nil
-		}
+	// Updating local class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(entry["ip6_class_parameters"].(string))
+	computedClassParameters := map[string]string{}
 
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				// Log the error
-				tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 address: %s (%s)\n", d.Get("name"), errMsg))
-			}
-		} else {
-			// Log the error
-			tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 address (oid): %s\n", d.Id()))
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
 		}
-
-		// Do not unset the local ID to avoid inconsistency
-
-		// Reporting a failure
-		return diag.Errorf("Unable to find IPv6 address: %s", d.Get("name").(string))
 	}
 
-	// Reporting a failure
-	return diag.FromErr(err)
+	d.Set("class_parameters", computedClassParameters)
+
+	return nil
 }
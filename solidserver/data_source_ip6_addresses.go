@@ -0,0 +1,172 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"strings"
+)
+
+func dataSourceip6addresses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6addressesRead,
+
+		Description: heredoc.Doc(`
+			IPv6 addresses allows to retrieve all the IPv6 addresses matching a query,
+			for instance to iterate over all the addresses tagged for a given environment.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to find the matching IPv6 addresses.",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to find the matching IPv6 addresses in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the matching IPv6 addresses are returned.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of IPv6 addresses to return (Default: 0, no limit).",
+				Optional:    true,
+				Default:     0,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The matching IPv6 addresses.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the IPv6 address.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The name of the space of the IPv6 address.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The name of the subnet of the IPv6 address.",
+							Computed:    true,
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Description: "The name of the pool of the IPv6 address.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IPv6 address.",
+							Computed:    true,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Description: "Device Name associated to the IPv6 address (Require a 'Device Manager' license).",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The short name or FQDN of the IPv6 address.",
+							Computed:    true,
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC Address of the IPv6 address.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The IPv6 address prefix.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The prefix_length associated to the IPv6 address.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IPv6 address.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IPv6 address.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceip6addressesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Free addresses are listed by SOLIDserver as well, only used ones are returned
+	query := "type='ip6'"
+
+	if d.Get("query").(string) != "" {
+		query += " AND (" + d.Get("query").(string) + ")"
+	}
+
+	entries, err := objectlistbyquery("rest/ip6_address6_list", query, d.Get("tags").(string), d.Get("orderby").(string), d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	addresses := []map[string]interface{}{}
+
+	for _, entry := range entries {
+		prefixLength, _ := strconv.Atoi(entrystring(entry, "subnet6_prefix"))
+		mac := entrystring(entry, "ip6_mac_addr")
+
+		if strings.HasPrefix(mac, "EIP:") {
+			mac = ""
+		}
+
+		addresses = append(addresses, map[string]interface{}{
+			"id":               entrystring(entry, "ip6_id"),
+			"space":            entrystring(entry, "site_name"),
+			"subnet":           entrystring(entry, "subnet6_name"),
+			"pool":             entrystring(entry, "pool6_name"),
+			"address":          hexip6toip6(entrystring(entry, "ip6_addr")),
+			"device":           entrystring(entry, "hostdev_name"),
+			"name":             entrystring(entry, "ip6_name"),
+			"mac":              mac,
+			"prefix":           hexip6toip6(entrystring(entry, "subnet6_start_ip6_addr")) + "/" + strconv.Itoa(prefixLength),
+			"prefix_size":      prefixLength,
+			"class":            entrystring(entry, "ip6_class_name"),
+			"class_parameters": entryclassparameters(entry, "ip6_class_parameters"),
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d IPv6 address(es) matching the query: %s\n", len(addresses), d.Get("query").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("query").(string) + "|" + d.Get("tags").(string) + "|" + d.Get("orderby").(string))))
+	d.Set("addresses", addresses)
+
+	return nil
+}
@@ -13,7 +13,7 @@ import (
 
 func dataSourceip6pool() *schema.Resource {
 	return &schema.Resource{
-		ReadContext: dataSourceippoolRead,
+		ReadContext: dataSourceip6poolRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -69,16 +69,38 @@ func dataSourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta int
 	s := meta.(*SOLIDserver)
 	d.SetId("")
 
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	// Pools and their parent subnet may be referenced by oid, range or name
+	poolInfo, poolErr := ip6poolinfobyname(siteID, d.Get("name").(string), d.Get("subnet").(string), meta)
+
+	if poolErr != nil {
+		// Reporting a failure
+		return diag.FromErr(poolErr)
+	}
+
+	if poolInfo == nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IPv6 pool: %s", d.Get("name").(string))
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "pool6_name like '" + d.Get("name").(string) + "' " +
-		"and site_name like '" + d.Get("space").(string) + "' " +
-		"and subnet6_name like '" + d.Get("subnet").(string) + "'"
+	whereClause := "pool6_id='" + poolInfo["id"].(string) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_pool6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -93,7 +115,7 @@ func dataSourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta int
 
 			prefix_size, _ := strconv.Atoi(buf[0]["subnet6_prefix"].(string))
 
-			d.Set("prefix", hexip6toip6(buf[0]["subnet_start_ip_addr"].(string))+"/"+buf[0]["subnet6_prefix"].(string))
+			d.Set("prefix", hexip6toip6(buf[0]["subnet6_start_ip6_addr"].(string))+"/"+buf[0]["subnet6_prefix"].(string))
 			d.Set("prefix_size", prefix_size)
 
 			d.Set("class", buf[0]["pool6_class_name"].(string))
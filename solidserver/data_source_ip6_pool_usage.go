@@ -0,0 +1,103 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceip6poolusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6poolusageRead,
+
+		Description: heredoc.Doc(`
+			IPv6 pool usage allows to retrieve the utilization of an IPv6 pool,
+			in order to trigger actions such as a new allocation once a threshold is reached.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 pool.",
+				Required:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The parent subnet of the IPv6 pool.",
+				Required:    true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The space associated to the IPv6 pool.",
+				Required:    true,
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Description: "The total number of addresses of the IPv6 pool (as a string, it may exceed 64-bit integers).",
+				Computed:    true,
+			},
+			"used": {
+				Type:        schema.TypeString,
+				Description: "The number of used addresses within the IPv6 pool (as a string).",
+				Computed:    true,
+			},
+			"free": {
+				Type:        schema.TypeString,
+				Description: "The number of free addresses within the IPv6 pool (as a string, it may exceed 64-bit integers).",
+				Computed:    true,
+			},
+			"used_percent": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of used addresses within the IPv6 pool.",
+				Computed:    true,
+			},
+			"dhcp_leases": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IPv6 pool leased by a DHCP server.",
+				Computed:    true,
+			},
+			"dhcp_statics": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IPv6 pool reserved as DHCP statics.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceip6poolusageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	whereClause := "pool6_name LIKE '" + d.Get("name").(string) + "'" +
+		" and site_name LIKE '" + d.Get("space").(string) + "'" +
+		" and subnet6_name LIKE '" + d.Get("subnet").(string) + "'"
+
+	pools, err := objectlist("rest/ip6_pool6_list", whereClause, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(pools) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read usage of IPv6 pool: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IPv6 pool: %s\n", d.Get("name").(string))
+	}
+
+	poolID := pools[0]["pool6_id"].(string)
+
+	if err := ipusageset(d, pools[0], "pool6_size", "pool6_ip6_used_size", "pool6_id='"+poolID+"'", true, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(poolID)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+)
+
+func dataSourceip6pools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6poolsRead,
+
+		Description: heredoc.Doc(`
+			IPv6 pools allows to retrieve all the IPv6 pools matching a query,
+			for instance to iterate over all the pools tagged for a given environment.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to find the matching IPv6 pools.",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to find the matching IPv6 pools in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the matching IPv6 pools are returned.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of IPv6 pools to return (Default: 0, no limit).",
+				Optional:    true,
+				Default:     0,
+			},
+			"pools": {
+				Type:        schema.TypeList,
+				Description: "The matching IPv6 pools.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the IPv6 pool.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IPv6 pool.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The parent subnet of the IPv6 pool.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The space associated to the IPv6 pool.",
+							Computed:    true,
+						},
+						"start": {
+							Type:        schema.TypeString,
+							Description: "The first address of the IPv6 pool.",
+							Computed:    true,
+						},
+						"end": {
+							Type:        schema.TypeString,
+							Description: "The last address of the IPv6 pool.",
+							Computed:    true,
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Description: "The size of the IPv6 pool.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The prefix of the parent subnet of the IPv6 pool.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The size prefix of the parent subnet of the IPv6 pool.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IPv6 pool.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IPv6 pool.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceip6poolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	entries, err := objectlistbyquery("rest/ip6_pool6_list", d.Get("query").(string), d.Get("tags").(string), d.Get("orderby").(string), d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	pools := []map[string]interface{}{}
+
+	for _, entry := range entries {
+		prefixLength, _ := strconv.Atoi(entrystring(entry, "subnet6_prefix"))
+
+		pools = append(pools, map[string]interface{}{
+			"id":               entrystring(entry, "pool6_id"),
+			"name":             entrystring(entry, "pool6_name"),
+			"subnet":           entrystring(entry, "subnet6_name"),
+			"space":            entrystring(entry, "site_name"),
+			"start":            hexip6toip6(entrystring(entry, "pool6_start_ip6_addr")),
+			"end":              hexip6toip6(entrystring(entry, "pool6_end_ip6_addr")),
+			"size":             entrystring(entry, "pool6_size"),
+			"prefix":           hexip6toip6(entrystring(entry, "subnet6_start_ip6_addr")) + "/" + strconv.Itoa(prefixLength),
+			"prefix_size":      prefixLength,
+			"class":            entrystring(entry, "pool6_class_name"),
+			"class_parameters": entryclassparameters(entry, "pool6_class_parameters"),
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d IPv6 pool(s) matching the query: %s\n", len(pools), d.Get("query").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("query").(string) + "|" + d.Get("tags").(string) + "|" + d.Get("orderby").(string))))
+	d.Set("pools", pools)
+
+	return nil
+}
@@ -59,6 +59,21 @@ func dataSourceip6subnet() *schema.Resource {
 				Description: "The  IPv6 subnet's computed gateway.",
 				Computed:    true,
 			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "The VLAN ID of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IPv6 subnet.",
@@ -82,8 +97,7 @@ func dataSourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "subnet6_name LIKE '" + d.Get("name").(string) + "'" +
-		" and site_name LIKE '" + d.Get("space").(string) + "'"
+	whereClause := "site_name='" + d.Get("space").(string) + "' AND " + ipsubnetreferenceclause(d.Get("name").(string), true)
 
 	parameters.Add("WHERE", whereClause)
 
@@ -94,7 +108,11 @@ func dataSourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta i
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
-		// Checking the answer
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return diag.Errorf("Ambiguous IPv6 subnet: %s, %d subnets match, reference it by prefix or by oid (subnet6_id:<oid>) instead\n", d.Get("name").(string), len(buf))
+		}
+
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.SetId(buf[0]["subnet6_id"].(string))
 
@@ -113,6 +131,7 @@ func dataSourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta i
 			}
 
 			d.Set("class", buf[0]["subnet6_class_name"].(string))
+			subnetvlanset(d, buf[0], true)
 
 			// Setting local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet6_class_parameters"].(string))
@@ -68,6 +68,21 @@ func dataSourceip6subnetquery() *schema.Resource {
 				Description: "The  IPv6 subnet's computed gateway.",
 				Computed:    true,
 			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "The VLAN ID of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN associated to the IPv6 subnet.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IPv6 subnet.",
@@ -122,6 +137,7 @@ func dataSourceip6subnetqueryRead(ctx context.Context, d *schema.ResourceData, m
 			}
 
 			d.Set("class", buf[0]["subnet6_class_name"].(string))
+			subnetvlanset(d, buf[0], true)
 
 			// Setting local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet6_class_parameters"].(string))
@@ -0,0 +1,97 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceip6subnetusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6subnetusageRead,
+
+		Description: heredoc.Doc(`
+			IPv6 subnet usage allows to retrieve the utilization of an IPv6 block or subnet,
+			in order to trigger actions such as a new allocation once a threshold is reached.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 subnet.",
+				Required:    true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The space associated to the IPv6 subnet.",
+				Required:    true,
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Description: "The total number of addresses of the IPv6 subnet (as a string, it may exceed 64-bit integers).",
+				Computed:    true,
+			},
+			"used": {
+				Type:        schema.TypeString,
+				Description: "The number of used addresses within the IPv6 subnet (as a string).",
+				Computed:    true,
+			},
+			"free": {
+				Type:        schema.TypeString,
+				Description: "The number of free addresses within the IPv6 subnet (as a string, it may exceed 64-bit integers).",
+				Computed:    true,
+			},
+			"used_percent": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of used addresses within the IPv6 subnet.",
+				Computed:    true,
+			},
+			"dhcp_leases": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IPv6 subnet leased by a DHCP server.",
+				Computed:    true,
+			},
+			"dhcp_statics": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IPv6 subnet reserved as DHCP statics.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceip6subnetusageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	whereClause := "subnet6_name LIKE '" + d.Get("name").(string) + "'" +
+		" and site_name LIKE '" + d.Get("space").(string) + "'"
+
+	subnets, err := objectlist("rest/ip6_block6_subnet6_list", whereClause, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(subnets) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read usage of IPv6 subnet: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IPv6 subnet: %s\n", d.Get("name").(string))
+	}
+
+	subnetID := subnets[0]["subnet6_id"].(string)
+
+	if err := ipusageset(d, subnets[0], "subnet6_size", "subnet6_ip6_used_size", "subnet6_id='"+subnetID+"'", true, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subnetID)
+
+	return nil
+}
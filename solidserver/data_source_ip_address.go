@@ -2,9 +2,6 @@ package solidserver
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
@@ -19,8 +16,9 @@ func dataSourceipaddress() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"space": {
 				Type:        schema.TypeString,
-				Description: "The name of the space of the IP address.",
-				Required:    true,
+				Description: "The name of the space of the IP address (Default: search across all the spaces).",
+				Optional:    true,
+				Computed:    true,
 			},
 			"subnet": {
 				Type:        schema.TypeString,
@@ -33,9 +31,11 @@ func dataSourceipaddress() *schema.Resource {
 				Computed:    true,
 			},
 			"address": {
-				Type:        schema.TypeString,
-				Description: "The IP address.",
-				Required:    true,
+				Type:         schema.TypeString,
+				Description:  "The IP address to look up.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"address", "name", "mac", "query"},
 			},
 			"device": {
 				Type:        schema.TypeString,
@@ -44,14 +44,21 @@ func dataSourceipaddress() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
-				Description: "The short name or FQDN of the IP address.",
+				Description: "The short name or FQDN of the IP address to look up.",
+				Optional:    true,
 				Computed:    true,
 			},
 			"mac": {
 				Type:        schema.TypeString,
-				Description: "The MAC Address of the IP address.",
+				Description: "The MAC Address of the IP address to look up.",
+				Optional:    true,
 				Computed:    true,
 			},
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to look up the IP address (ex: \"ip_class_parameters LIKE '%owner=team1%'\").",
+				Optional:    true,
+			},
 			"prefix": {
 				Type:        schema.TypeString,
 				Description: "The IP address prefix.",
@@ -82,74 +89,48 @@ func dataSourceipaddress() *schema.Resource {
 }
 
 func dataSourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	s := meta.(*SOLIDserver)
-
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+d.Get("space").(string)+"' AND ip_addr='"+iptohexip(d.Get("address").(string))+"'")
-
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_address_list", &parameters)
-
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
-
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			d.SetId(buf[0]["ip_id"].(string))
-			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("subnet", buf[0]["subnet_name"].(string))
-			d.Set("pool", buf[0]["pool_name"].(string))
-			d.Set("name", buf[0]["name"].(string))
-			d.Set("device", buf[0]["hostdev_name"].(string))
-
-			subnetSize, _ := strconv.Atoi(buf[0]["subnet_size"].(string))
-			prefixLength := sizetoprefixlength(subnetSize)
-
-			d.Set("prefix", hexiptoip(buf[0]["subnet_start_ip_addr"].(string))+"/"+strconv.Itoa(prefixLength))
-			d.Set("prefix_size", prefixLength)
-			d.Set("netmask", prefixlengthtohexip(prefixLength))
+	// Looking up the IP address by address, name, MAC address or query
+	entry, err := ipaddresslookup(ipaddresslookupclause(d, false), false, meta)
 
-			if macIgnore, _ := regexp.MatchString("^EIP:", buf[0]["mac_addr"].(string)); !macIgnore {
-				d.Set("mac", buf[0]["mac_addr"].(string))
-			} else {
-				d.Set("mac", "")
-			}
-
-			d.Set("class", buf[0]["ip_class_name"].(string))
-
-			// Updating local class_parameters
-			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip_class_parameters"].(string))
-			computedClassParameters := map[string]string{}
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
 
-			for ck := range retrievedClassParameters {
-				if ck != "gateway" {
-					computedClassParameters[ck] = retrievedClassParameters[ck][0]
-				}
-			}
+	d.SetId(entry["ip_id"].(string))
+	d.Set("space", entry["site_name"].(string))
+	d.Set("address", hexiptoip(entry["ip_addr"].(string)))
+	d.Set("subnet", entry["subnet_name"].(string))
+	d.Set("pool", entry["pool_name"].(string))
+	d.Set("name", entry["name"].(string))
+	d.Set("device", entry["hostdev_name"].(string))
+
+	subnetSize, _ := strconv.Atoi(entry["subnet_size"].(string))
+	prefixLength := sizetoprefixlength(subnetSize)
+
+	d.Set("prefix", hexiptoip(entry["subnet_start_ip_addr"].(string))+"/"+strconv.Itoa(prefixLength))
+	d.Set("prefix_size", prefixLength)
+	d.Set("netmask", prefixlengthtohexip(prefixLength))
+
+	if macIgnore, _ := regexp.MatchString("^EIP:", entry["mac_addr"].(string)); !macIgnore {
+		d.Set("mac", entry["mac_addr"].(string))
+	} else {
+		d.Set("mac", "")
+	}
 
-			d.Set("class_parameters", computedClassParameters)
+	d.Set("class", entry["ip_class_name"].(string))
 
-			return nil
-		}
+	// Updating local class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(entry["ip_class_parameters"].(string))
+	computedClassParameters := map[string]string{}
 
-		if len(buf) > 0 {
-			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				// Log the error
-				tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address: %s (%s)\n", d.Get("name"), errMsg))
-			}
-		} else {
-			// Log the error
-			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address (oid): %s\n", d.Id()))
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
 		}
-
-		// Do not unset the local ID to avoid inconsistency
-
-		// Reporting a failure
-		return diag.Errorf("Unable to find IP address: %s", d.Get("name").(string))
 	}
 
-	// Reporting a failure
-	return diag.FromErr(err)
+	d.Set("class_parameters", computedClassParameters)
+
+	return nil
 }
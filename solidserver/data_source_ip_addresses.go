@@ -0,0 +1,179 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"strings"
+)
+
+func dataSourceipaddresses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipaddressesRead,
+
+		Description: heredoc.Doc(`
+			IP addresses allows to retrieve all the IP addresses matching a query,
+			for instance to iterate over all the addresses tagged for a given environment.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to find the matching IP addresses.",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to find the matching IP addresses in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the matching IP addresses are returned.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of IP addresses to return (Default: 0, no limit).",
+				Optional:    true,
+				Default:     0,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The matching IP addresses.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the IP address.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The name of the space of the IP address.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The name of the subnet of the IP address.",
+							Computed:    true,
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Description: "The name of the pool of the IP address.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP address.",
+							Computed:    true,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Description: "Device Name associated to the IP address (Require a 'Device Manager' license).",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The short name or FQDN of the IP address.",
+							Computed:    true,
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC Address of the IP address.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The IP address prefix.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The prefix_length associated to the IP address.",
+							Computed:    true,
+						},
+						"netmask": {
+							Type:        schema.TypeString,
+							Description: "The provisionned IP address netmask.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IP address.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IP address.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipaddressesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Free addresses are listed by SOLIDserver as well, only used ones are returned
+	query := "type='ip'"
+
+	if d.Get("query").(string) != "" {
+		query += " AND (" + d.Get("query").(string) + ")"
+	}
+
+	entries, err := objectlistbyquery("rest/ip_address_list", query, d.Get("tags").(string), d.Get("orderby").(string), d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	addresses := []map[string]interface{}{}
+
+	for _, entry := range entries {
+		subnetSize, _ := strconv.Atoi(entrystring(entry, "subnet_size"))
+		prefixLength := sizetoprefixlength(subnetSize)
+		mac := entrystring(entry, "mac_addr")
+
+		if strings.HasPrefix(mac, "EIP:") {
+			mac = ""
+		}
+
+		addresses = append(addresses, map[string]interface{}{
+			"id":               entrystring(entry, "ip_id"),
+			"space":            entrystring(entry, "site_name"),
+			"subnet":           entrystring(entry, "subnet_name"),
+			"pool":             entrystring(entry, "pool_name"),
+			"address":          hexiptoip(entrystring(entry, "ip_addr")),
+			"device":           entrystring(entry, "hostdev_name"),
+			"name":             entrystring(entry, "name"),
+			"mac":              mac,
+			"prefix":           hexiptoip(entrystring(entry, "subnet_start_ip_addr")) + "/" + strconv.Itoa(prefixLength),
+			"prefix_size":      prefixLength,
+			"netmask":          prefixlengthtohexip(prefixLength),
+			"class":            entrystring(entry, "ip_class_name"),
+			"class_parameters": entryclassparameters(entry, "ip_class_parameters"),
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d IP address(es) matching the query: %s\n", len(addresses), d.Get("query").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("query").(string) + "|" + d.Get("tags").(string) + "|" + d.Get("orderby").(string))))
+	d.Set("addresses", addresses)
+
+	return nil
+}
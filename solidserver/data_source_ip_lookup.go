@@ -0,0 +1,295 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+func dataSourceiplookup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceiplookupRead,
+
+		Description: heredoc.Doc(`
+			IP lookup allows to find where an IP or IPv6 address lives: every space containing it,
+			along with the block, subnet and pool chain, the address object and the DNS names pointing to it.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "The IP or IPv6 address to look up.",
+				ValidateFunc: validation.IsIPAddress,
+				Required:     true,
+			},
+			"locations": {
+				Type:        schema.TypeList,
+				Description: "The locations of the address, one per space containing it.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The name of the space containing the address.",
+							Computed:    true,
+						},
+						"hierarchy": {
+							Type:        schema.TypeList,
+							Description: "The blocks, subnets and pool containing the address, from the top level block down to the pool.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Description: "The type of the object (block, subnet or pool).",
+										Computed:    true,
+									},
+									"id": {
+										Type:        schema.TypeString,
+										Description: "The oid of the object.",
+										Computed:    true,
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the object.",
+										Computed:    true,
+									},
+									"start": {
+										Type:        schema.TypeString,
+										Description: "The first address of the object.",
+										Computed:    true,
+									},
+									"end": {
+										Type:        schema.TypeString,
+										Description: "The last address of the object.",
+										Computed:    true,
+									},
+									"prefix": {
+										Type:        schema.TypeString,
+										Description: "The prefix of the block or subnet (empty for pools).",
+										Computed:    true,
+									},
+									"class": {
+										Type:        schema.TypeString,
+										Description: "The class associated to the object.",
+										Computed:    true,
+									},
+									"class_parameters": {
+										Type:        schema.TypeMap,
+										Description: "The class parameters associated to the object.",
+										Computed:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"address_id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the address object, empty if the address is not registered within the space.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the address object.",
+							Computed:    true,
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC Address of the address object.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the address object.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the address object.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"dns_names": {
+				Type:        schema.TypeList,
+				Description: "The names of the DNS records (A or AAAA) pointing to the address.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceiplookupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	address := d.Get("address").(string)
+	v6 := iptohexip(address) == ""
+
+	// Mapping the objects of the relevant address family
+	subnetService, subnetKey, subnetStart, subnetEnd, subnetClassKey := "rest/ip_block_subnet_list", "subnet", "start_ip_addr", "end_ip_addr", "subnet"
+	poolService, poolKey, poolStart, poolEnd := "rest/ip_pool_list", "pool", "start_ip_addr", "end_ip_addr"
+	addressService, addressKey, addressClassKey, macKey, nameKey := "rest/ip_address_list", "ip_addr", "ip", "mac_addr", "name"
+	hexAddr := iptohexip(address)
+	toIP := hexiptoip
+	addressType, rrType := "ip", "A"
+
+	if v6 {
+		subnetService, subnetKey, subnetStart, subnetEnd, subnetClassKey = "rest/ip6_block6_subnet6_list", "subnet6", "start_ip6_addr", "end_ip6_addr", "subnet6"
+		poolService, poolKey, poolStart, poolEnd = "rest/ip6_pool6_list", "pool6", "start_ip6_addr", "end_ip6_addr"
+		addressService, addressKey, addressClassKey, macKey, nameKey = "rest/ip6_address6_list", "ip6_addr", "ip6", "ip6_mac_addr", "ip6_name"
+		hexAddr = ip6tohexip6(shortip6tolongip6(address))
+		toIP = hexip6toip6
+		addressType, rrType = "ip6", "AAAA"
+	}
+
+	// Gathering the blocks and subnets containing the address, top level ones first
+	subnets, err := objectlistbyquery(subnetService, subnetStart+"<='"+hexAddr+"' AND "+subnetEnd+">='"+hexAddr+"'", "", "subnet_level", 0, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	pools, err := objectlistbyquery(poolService, poolStart+"<='"+hexAddr+"' AND "+poolEnd+">='"+hexAddr+"'", "", "", 0, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	addresses, err := objectlistbyquery(addressService, addressKey+"='"+hexAddr+"' AND type='"+addressType+"'", "", "", 0, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Building one location per space, in the order the spaces were found
+	spaces := []string{}
+	locations := map[string]map[string]interface{}{}
+
+	location := func(space string) map[string]interface{} {
+		if _, locationExist := locations[space]; !locationExist {
+			spaces = append(spaces, space)
+			locations[space] = map[string]interface{}{
+				"space":            space,
+				"hierarchy":        []map[string]interface{}{},
+				"address_id":       "",
+				"name":             "",
+				"mac":              "",
+				"class":            "",
+				"class_parameters": map[string]string{},
+			}
+		}
+
+		return locations[space]
+	}
+
+	for _, entry := range subnets {
+		objectType := "subnet"
+		prefix := ""
+
+		if entrystring(entry, "subnet_level") == "0" {
+			objectType = "block"
+		}
+
+		if v6 {
+			prefix = toIP(entrystring(entry, subnetStart)) + "/" + entrystring(entry, "subnet6_prefix")
+		} else {
+			subnetSize, _ := strconv.Atoi(entrystring(entry, "subnet_size"))
+			prefix = toIP(entrystring(entry, subnetStart)) + "/" + strconv.Itoa(sizetoprefixlength(subnetSize))
+		}
+
+		l := location(entrystring(entry, "site_name"))
+		l["hierarchy"] = append(l["hierarchy"].([]map[string]interface{}), map[string]interface{}{
+			"type":             objectType,
+			"id":               entrystring(entry, subnetKey+"_id"),
+			"name":             entrystring(entry, subnetKey+"_name"),
+			"start":            toIP(entrystring(entry, subnetStart)),
+			"end":              toIP(entrystring(entry, subnetEnd)),
+			"prefix":           prefix,
+			"class":            entrystring(entry, subnetClassKey+"_class_name"),
+			"class_parameters": entryclassparameters(entry, subnetClassKey+"_class_parameters"),
+		})
+	}
+
+	for _, entry := range pools {
+		l := location(entrystring(entry, "site_name"))
+		l["hierarchy"] = append(l["hierarchy"].([]map[string]interface{}), map[string]interface{}{
+			"type":             "pool",
+			"id":               entrystring(entry, poolKey+"_id"),
+			"name":             entrystring(entry, poolKey+"_name"),
+			"start":            toIP(entrystring(entry, poolStart)),
+			"end":              toIP(entrystring(entry, poolEnd)),
+			"prefix":           "",
+			"class":            entrystring(entry, poolKey+"_class_name"),
+			"class_parameters": entryclassparameters(entry, poolKey+"_class_parameters"),
+		})
+	}
+
+	for _, entry := range addresses {
+		l := location(entrystring(entry, "site_name"))
+		l["address_id"] = entrystring(entry, addressClassKey+"_id")
+		l["name"] = entrystring(entry, nameKey)
+		l["class"] = entrystring(entry, addressClassKey+"_class_name")
+		l["class_parameters"] = entryclassparameters(entry, addressClassKey+"_class_parameters")
+
+		if macIgnore, _ := regexp.MatchString("^EIP:", entrystring(entry, macKey)); !macIgnore {
+			l["mac"] = entrystring(entry, macKey)
+		}
+	}
+
+	result := []map[string]interface{}{}
+
+	for _, space := range spaces {
+		result = append(result, locations[space])
+	}
+
+	// Gathering the DNS names pointing to the address, whatever the way it is written
+	valueClause := "value1='" + address + "'"
+
+	if v6 {
+		valueClause = "(value1='" + longip6toshortip6(address) + "' OR value1='" + shortip6tolongip6(address) + "')"
+	}
+
+	records, err := objectlistbyquery("rest/dns_rr_list", "rr_type='"+rrType+"' AND "+valueClause, "", "", 0, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	dnsNames := []string{}
+
+	for _, record := range records {
+		if name := entrystring(record, "rr_full_name"); name != "" && stringOffsetInSlice(name, dnsNames) == -1 {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+
+	sort.Strings(dnsNames)
+
+	tflog.Debug(ctx, fmt.Sprintf("Found address %s in %d space(s) and %d DNS name(s)\n", address, len(result), len(dnsNames)))
+
+	d.SetId(hexAddr)
+	d.Set("locations", result)
+	d.Set("dns_names", dnsNames)
+
+	return nil
+}
@@ -74,11 +74,33 @@ func dataSourceippoolRead(ctx context.Context, d *schema.ResourceData, meta inte
 	s := meta.(*SOLIDserver)
 	d.SetId("")
 
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	// Pools and their parent subnet may be referenced by oid, range or name
+	poolInfo, poolErr := ippoolinfobyname(siteID, d.Get("name").(string), d.Get("subnet").(string), meta)
+
+	if poolErr != nil {
+		// Reporting a failure
+		return diag.FromErr(poolErr)
+	}
+
+	if poolInfo == nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IP pool: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP pool: %s", d.Get("name").(string))
+	}
+
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "pool_name like '" + d.Get("name").(string) + "' " +
-		"and site_name like '" + d.Get("space").(string) + "' " +
-		"and subnet_name like '" + d.Get("subnet").(string) + "'"
+	whereClause := "pool_id='" + poolInfo["id"].(string) + "'"
 
 	parameters.Add("WHERE", whereClause)
 
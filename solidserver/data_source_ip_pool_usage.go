@@ -0,0 +1,103 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceippoolusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceippoolusageRead,
+
+		Description: heredoc.Doc(`
+			IP pool usage allows to retrieve the utilization of an IP pool,
+			in order to trigger actions such as a new allocation once a threshold is reached.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP pool.",
+				Required:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The parent subnet of the IP pool.",
+				Required:    true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The space associated to the IP pool.",
+				Required:    true,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "The total number of addresses of the IP pool.",
+				Computed:    true,
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Description: "The number of used addresses within the IP pool.",
+				Computed:    true,
+			},
+			"free": {
+				Type:        schema.TypeInt,
+				Description: "The number of free addresses within the IP pool.",
+				Computed:    true,
+			},
+			"used_percent": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of used addresses within the IP pool.",
+				Computed:    true,
+			},
+			"dhcp_leases": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IP pool leased by a DHCP server.",
+				Computed:    true,
+			},
+			"dhcp_statics": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IP pool reserved as DHCP statics.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceippoolusageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	whereClause := "pool_name LIKE '" + d.Get("name").(string) + "'" +
+		" and site_name LIKE '" + d.Get("space").(string) + "'" +
+		" and subnet_name LIKE '" + d.Get("subnet").(string) + "'"
+
+	pools, err := objectlist("rest/ip_pool_list", whereClause, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(pools) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read usage of IP pool: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP pool: %s\n", d.Get("name").(string))
+	}
+
+	poolID := pools[0]["pool_id"].(string)
+
+	if err := ipusageset(d, pools[0], "pool_size", "pool_ip_used_size", "pool_id='"+poolID+"'", false, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(poolID)
+
+	return nil
+}
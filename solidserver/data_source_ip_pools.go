@@ -0,0 +1,154 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+)
+
+func dataSourceippools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceippoolsRead,
+
+		Description: heredoc.Doc(`
+			IP pools allows to retrieve all the IP pools matching a query,
+			for instance to iterate over all the pools tagged for a given environment.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to find the matching IP pools.",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to find the matching IP pools in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the matching IP pools are returned.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of IP pools to return (Default: 0, no limit).",
+				Optional:    true,
+				Default:     0,
+			},
+			"pools": {
+				Type:        schema.TypeList,
+				Description: "The matching IP pools.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the IP pool.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IP pool.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The parent subnet of the IP pool.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The space associated to the IP pool.",
+							Computed:    true,
+						},
+						"start": {
+							Type:        schema.TypeString,
+							Description: "The first address of the IP pool.",
+							Computed:    true,
+						},
+						"end": {
+							Type:        schema.TypeString,
+							Description: "The last address of the IP pool.",
+							Computed:    true,
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Description: "The size of the IP pool.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The prefix of the parent subnet of the IP pool.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The size prefix of the parent subnet of the IP pool.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IP pool.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IP pool.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceippoolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	entries, err := objectlistbyquery("rest/ip_pool_list", d.Get("query").(string), d.Get("tags").(string), d.Get("orderby").(string), d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	pools := []map[string]interface{}{}
+
+	for _, entry := range entries {
+		subnetSize, _ := strconv.Atoi(entrystring(entry, "subnet_size"))
+		prefixLength := sizetoprefixlength(subnetSize)
+
+		pools = append(pools, map[string]interface{}{
+			"id":               entrystring(entry, "pool_id"),
+			"name":             entrystring(entry, "pool_name"),
+			"subnet":           entrystring(entry, "subnet_name"),
+			"space":            entrystring(entry, "site_name"),
+			"start":            hexiptoip(entrystring(entry, "start_ip_addr")),
+			"end":              hexiptoip(entrystring(entry, "end_ip_addr")),
+			"size":             entrystring(entry, "pool_size"),
+			"prefix":           hexiptoip(entrystring(entry, "subnet_start_ip_addr")) + "/" + strconv.Itoa(prefixLength),
+			"prefix_size":      prefixLength,
+			"class":            entrystring(entry, "pool_class_name"),
+			"class_parameters": entryclassparameters(entry, "pool_class_parameters"),
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d IP pool(s) matching the query: %s\n", len(pools), d.Get("query").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("query").(string) + "|" + d.Get("tags").(string) + "|" + d.Get("orderby").(string))))
+	d.Set("pools", pools)
+
+	return nil
+}
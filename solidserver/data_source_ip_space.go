@@ -27,6 +27,19 @@ func dataSourceipspace() *schema.Resource {
 				Description: "The name of the IP space.",
 				Required:    true,
 			},
+			"parent_space": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP space within the space hierarchy (VLSM).",
+				Computed:    true,
+			},
+			"child_spaces": {
+				Type:        schema.TypeList,
+				Description: "The names of the child IP spaces within the space hierarchy (VLSM).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP space.",
@@ -61,8 +74,18 @@ func dataSourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta int
 			d.SetId(buf[0]["site_id"].(string))
 
 			d.Set("name", buf[0]["site_name"].(string))
+			d.Set("parent_space", ipsiteparentname(buf[0]))
 			d.Set("class", buf[0]["site_class_name"].(string))
 
+			children, childrenErr := ipsitechildrennames(buf[0]["site_id"].(string), meta)
+
+			if childrenErr != nil {
+				// Reporting a failure
+				return diag.FromErr(childrenErr)
+			}
+
+			d.Set("child_spaces", children)
+
 			// Updating local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["site_class_parameters"].(string))
 			computedClassParameters := map[string]string{}
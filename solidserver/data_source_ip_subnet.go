@@ -56,6 +56,21 @@ func dataSourceipsubnet() *schema.Resource {
 				Description: "The subnet's computed gateway.",
 				Computed:    true,
 			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "The VLAN ID of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP subnet.",
@@ -79,8 +94,7 @@ func dataSourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	// Building parameters
 	parameters := url.Values{}
-	whereClause := "subnet_name LIKE '" + d.Get("name").(string) + "'" +
-		" and site_name LIKE '" + d.Get("space").(string) + "'"
+	whereClause := "site_name='" + d.Get("space").(string) + "' AND " + ipsubnetreferenceclause(d.Get("name").(string), false)
 
 	parameters.Add("WHERE", whereClause)
 
@@ -91,7 +105,11 @@ func dataSourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta in
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
-		// Checking the answer
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return diag.Errorf("Ambiguous IP subnet: %s, %d subnets match, reference it by prefix or by oid (subnet_id:<oid>) instead\n", d.Get("name").(string), len(buf))
+		}
+
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.SetId(buf[0]["subnet_id"].(string))
 
@@ -113,6 +131,7 @@ func dataSourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta in
 			}
 
 			d.Set("class", buf[0]["subnet_class_name"].(string))
+			subnetvlanset(d, buf[0], true)
 
 			// Setting local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet_class_parameters"].(string))
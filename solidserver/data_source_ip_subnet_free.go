@@ -0,0 +1,118 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+)
+
+func dataSourceipsubnetfree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetfreeRead,
+
+		Description: heredoc.Doc(`
+			IP subnet free allows to retrieve the candidate prefixes available within an IP block for a given prefix size, without creating anything.
+			The first candidate is the one an IP subnet would get if created right away.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP block.",
+				Required:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP block/subnet into which looking for free prefixes.",
+				Required:    true,
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The expected prefix length (ex: 24 for a '/24').",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The network address of the first candidate prefix.",
+				Computed:    true,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The first candidate prefix.",
+				Computed:    true,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The network addresses of the candidate prefixes, in order.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"prefixes": {
+				Type:        schema.TypeList,
+				Description: "The candidate prefixes, in order.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetfreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP space: %s\n", d.Get("space").(string))
+	}
+
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil || blockInfo == nil {
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP block: %s\n", d.Get("block").(string))
+	}
+
+	prefixSize := d.Get("prefix_size").(int)
+	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), "", prefixSize, meta)
+
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	if len(subnetAddresses) == 0 {
+		// Reporting a failure
+		return diag.Errorf("Unable to find a free /%d prefix in IP block: %s\n", prefixSize, d.Get("block").(string))
+	}
+
+	addresses := []string{}
+	prefixes := []string{}
+
+	for _, hexAddr := range subnetAddresses {
+		addresses = append(addresses, hexiptoip(hexAddr))
+		prefixes = append(prefixes, hexiptoip(hexAddr)+"/"+strconv.Itoa(prefixSize))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d free /%d prefix(es) in IP block: %s\n", len(prefixes), prefixSize, d.Get("block").(string)))
+
+	d.SetId(blockInfo["id"].(string) + "/" + strconv.Itoa(prefixSize))
+	d.Set("address", addresses[0])
+	d.Set("prefix", prefixes[0])
+	d.Set("addresses", addresses)
+	d.Set("prefixes", prefixes)
+
+	return nil
+}
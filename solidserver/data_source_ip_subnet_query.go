@@ -69,6 +69,21 @@ func dataSourceipsubnetquery() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "The VLAN ID of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN associated to the IP subnet.",
+				Computed:    true,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP subnet.",
@@ -120,6 +135,7 @@ func dataSourceipsubnetqueryRead(ctx context.Context, d *schema.ResourceData, me
 			d.Set("netmask", prefixlengthtohexip(prefix_length))
 
 			d.Set("class", buf[0]["subnet_class_name"].(string))
+			subnetvlanset(d, buf[0], true)
 
 			// Setting local class_parameters
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["subnet_class_parameters"].(string))
@@ -0,0 +1,97 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceipsubnetusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetusageRead,
+
+		Description: heredoc.Doc(`
+			IP subnet usage allows to retrieve the utilization of an IP block or subnet,
+			in order to trigger actions such as a new allocation once a threshold is reached.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP subnet.",
+				Required:    true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The space associated to the IP subnet.",
+				Required:    true,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "The total number of addresses of the IP subnet.",
+				Computed:    true,
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Description: "The number of used addresses within the IP subnet.",
+				Computed:    true,
+			},
+			"free": {
+				Type:        schema.TypeInt,
+				Description: "The number of free addresses within the IP subnet.",
+				Computed:    true,
+			},
+			"used_percent": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of used addresses within the IP subnet.",
+				Computed:    true,
+			},
+			"dhcp_leases": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IP subnet leased by a DHCP server.",
+				Computed:    true,
+			},
+			"dhcp_statics": {
+				Type:        schema.TypeInt,
+				Description: "The number of addresses of the IP subnet reserved as DHCP statics.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetusageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	whereClause := "subnet_name LIKE '" + d.Get("name").(string) + "'" +
+		" and site_name LIKE '" + d.Get("space").(string) + "'"
+
+	subnets, err := objectlist("rest/ip_block_subnet_list", whereClause, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(subnets) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to read usage of IP subnet: %s\n", d.Get("name").(string)))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP subnet: %s\n", d.Get("name").(string))
+	}
+
+	subnetID := subnets[0]["subnet_id"].(string)
+
+	if err := ipusageset(d, subnets[0], "subnet_size", "subnet_ip_used_size", "subnet_id='"+subnetID+"'", false, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subnetID)
+
+	return nil
+}
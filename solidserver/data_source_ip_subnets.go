@@ -0,0 +1,175 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+)
+
+func dataSourceipsubnets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetsRead,
+
+		Description: heredoc.Doc(`
+			IP subnets allows to retrieve all the IP blocks and subnets matching a query,
+			for instance to iterate over all the subnets tagged for a given environment.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query used to find the matching subnets.",
+				Required:    true,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to find the matching subnets in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the matching subnets are returned.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of subnets to return (Default: 0, no limit).",
+				Optional:    true,
+				Default:     0,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The matching IP subnets.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The oid of the IP subnet.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IP subnet.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The space associated to the IP subnet.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP subnet address.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The IP subnet prefix.",
+							Computed:    true,
+						},
+						"prefix_size": {
+							Type:        schema.TypeInt,
+							Description: "The IP subnet's prefix length (ex: 24 for a '/24').",
+							Computed:    true,
+						},
+						"netmask": {
+							Type:        schema.TypeString,
+							Description: "The IP subnet netmask.",
+							Computed:    true,
+						},
+						"terminal": {
+							Type:        schema.TypeBool,
+							Description: "The terminal property of the IP subnet.",
+							Computed:    true,
+						},
+						"gateway": {
+							Type:        schema.TypeString,
+							Description: "The subnet's computed gateway.",
+							Computed:    true,
+						},
+						"vlan_domain": {
+							Type:        schema.TypeString,
+							Description: "The name of the VLAN domain of the VLAN associated to the IP subnet.",
+							Computed:    true,
+						},
+						"vlan_id": {
+							Type:        schema.TypeInt,
+							Description: "The VLAN ID of the VLAN associated to the IP subnet.",
+							Computed:    true,
+						},
+						"vlan": {
+							Type:        schema.TypeString,
+							Description: "The name of the VLAN associated to the IP subnet.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IP subnet.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the IP subnet.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	entries, err := objectlistbyquery("rest/ip_block_subnet_list", d.Get("query").(string), d.Get("tags").(string), d.Get("orderby").(string), d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	subnets := []map[string]interface{}{}
+
+	for _, entry := range entries {
+		address := hexiptoip(entrystring(entry, "start_ip_addr"))
+		subnetSize, _ := strconv.Atoi(entrystring(entry, "subnet_size"))
+		prefixLength := sizetoprefixlength(subnetSize)
+		vlanID, _ := strconv.Atoi(entrystring(entry, "vlmvlan_vlan_id"))
+		classParameters := entryclassparameters(entry, "subnet_class_parameters")
+
+		subnets = append(subnets, map[string]interface{}{
+			"id":               entrystring(entry, "subnet_id"),
+			"name":             entrystring(entry, "subnet_name"),
+			"space":            entrystring(entry, "site_name"),
+			"address":          address,
+			"prefix":           address + "/" + strconv.Itoa(prefixLength),
+			"prefix_size":      prefixLength,
+			"netmask":          prefixlengthtohexip(prefixLength),
+			"terminal":         entrystring(entry, "is_terminal") == "1",
+			"gateway":          classParameters["gateway"],
+			"vlan_domain":      entrystring(entry, "vlmdomain_name"),
+			"vlan_id":          vlanID,
+			"vlan":             entrystring(entry, "vlmvlan_name"),
+			"class":            entrystring(entry, "subnet_class_name"),
+			"class_parameters": entryclassparameters(entry, "subnet_class_parameters", "gateway"),
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d IP subnet(s) matching the query: %s\n", len(subnets), d.Get("query").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("query").(string) + "|" + d.Get("tags").(string) + "|" + d.Get("orderby").(string))))
+	d.Set("subnets", subnets)
+
+	return nil
+}
@@ -51,18 +51,38 @@ func Provider() *schema.Provider {
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-9]\.[0-9]\.[0-9]\.([pP][0-9]+[a-z]?)?)?$`), "Invalid Version Number"),
 				Description:  "SOLIDServer Version in case API user does not have admin permissions",
 			},
+			"protect_non_empty": {
+				Type:        schema.TypeBool,
+				Required:    false,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDServer_PROTECTNONEMPTY", false),
+				Description: "Refuse to destroy spaces, blocks, subnets and zones still holding objects not managed by terraform (Default : disabled)",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"solidserver_ip_space":         dataSourceipspace(),
+			"solidserver_ip_lookup":        dataSourceiplookup(),
 			"solidserver_ip_subnet":        dataSourceipsubnet(),
 			"solidserver_ip_subnet_query":  dataSourceipsubnetquery(),
 			"solidserver_ip6_subnet":       dataSourceip6subnet(),
 			"solidserver_ip6_subnet_query": dataSourceip6subnetquery(),
+			"solidserver_ip_subnet_free":   dataSourceipsubnetfree(),
+			"solidserver_ip6_subnet_free":  dataSourceip6subnetfree(),
+			"solidserver_ip_subnet_usage":  dataSourceipsubnetusage(),
+			"solidserver_ip6_subnet_usage": dataSourceip6subnetusage(),
+			"solidserver_ip_subnets":       dataSourceipsubnets(),
+			"solidserver_ip6_subnets":      dataSourceip6subnets(),
 			"solidserver_ip_pool":          dataSourceippool(),
 			"solidserver_ip6_pool":         dataSourceip6pool(),
+			"solidserver_ip_pool_usage":    dataSourceippoolusage(),
+			"solidserver_ip6_pool_usage":   dataSourceip6poolusage(),
+			"solidserver_ip_pools":         dataSourceippools(),
+			"solidserver_ip6_pools":        dataSourceip6pools(),
 			"solidserver_ip_address":       dataSourceipaddress(),
 			"solidserver_ip6_address":      dataSourceip6address(),
+			"solidserver_ip_addresses":     dataSourceipaddresses(),
+			"solidserver_ip6_addresses":    dataSourceip6addresses(),
 			"solidserver_ip_ptr":           dataSourceipptr(),
 			"solidserver_ip6_ptr":          dataSourceip6ptr(),
 			"solidserver_dns_smart":        dataSourcednssmart(),
@@ -75,33 +95,40 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"solidserver_ip_space":         resourceipspace(),
-			"solidserver_ip_subnet":        resourceipsubnet(),
-			"solidserver_ip6_subnet":       resourceip6subnet(),
-			"solidserver_ip_pool":          resourceippool(),
-			"solidserver_ip6_pool":         resourceip6pool(),
-			"solidserver_ip_address":       resourceipaddress(),
-			"solidserver_ip6_address":      resourceip6address(),
-			"solidserver_ip_alias":         resourceipalias(),
-			"solidserver_ip6_alias":        resourceip6alias(),
-			"solidserver_ip_mac":           resourceipmac(),
-			"solidserver_ip6_mac":          resourceip6mac(),
-			"solidserver_device":           resourcedevice(),
-			"solidserver_vlan_domain":      resourcevlandomain(),
-			"solidserver_vlan":             resourcevlan(),
-			"solidserver_dns_smart":        resourcednssmart(),
-			"solidserver_dns_server":       resourcednsserver(),
-			"solidserver_dns_view":         resourcednsview(),
-			"solidserver_dns_zone":         resourcednszone(),
-			"solidserver_dns_forward_zone": resourcednsforwardzone(),
-			"solidserver_dns_rr":           resourcednsrr(),
-			"solidserver_app_application":  resourceapplication(),
-			"solidserver_app_pool":         resourceapplicationpool(),
-			"solidserver_app_node":         resourceapplicationnode(),
-			"solidserver_user":             resourceuser(),
-			"solidserver_usergroup":        resourceusergroup(),
-			"solidserver_cdb":              resourcecdb(),
-			"solidserver_cdb_data":         resourcecdbdata(),
+			"solidserver_ip_space":            resourceipspace(),
+			"solidserver_ip_subnet":           resourceipsubnet(),
+			"solidserver_ip6_subnet":          resourceip6subnet(),
+			"solidserver_ip6_delegation_pool": resourceip6delegationpool(),
+			"solidserver_ip_subnet_split":     resourceipsubnetsplit(),
+			"solidserver_ip_subnet_merge":     resourceipsubnetmerge(),
+			"solidserver_ip_plan":             resourceipplan(),
+			"solidserver_dualstack_subnet":    resourcedualstacksubnet(),
+			"solidserver_ip_pool":             resourceippool(),
+			"solidserver_ip6_pool":            resourceip6pool(),
+			"solidserver_ip_address":          resourceipaddress(),
+			"solidserver_ip6_address":         resourceip6address(),
+			"solidserver_ip_address_range":    resourceipaddressrange(),
+			"solidserver_host":                resourcehost(),
+			"solidserver_ip_alias":            resourceipalias(),
+			"solidserver_ip6_alias":           resourceip6alias(),
+			"solidserver_ip_mac":              resourceipmac(),
+			"solidserver_ip6_mac":             resourceip6mac(),
+			"solidserver_device":              resourcedevice(),
+			"solidserver_vlan_domain":         resourcevlandomain(),
+			"solidserver_vlan":                resourcevlan(),
+			"solidserver_dns_smart":           resourcednssmart(),
+			"solidserver_dns_server":          resourcednsserver(),
+			"solidserver_dns_view":            resourcednsview(),
+			"solidserver_dns_zone":            resourcednszone(),
+			"solidserver_dns_forward_zone":    resourcednsforwardzone(),
+			"solidserver_dns_rr":              resourcednsrr(),
+			"solidserver_app_application":     resourceapplication(),
+			"solidserver_app_pool":            resourceapplicationpool(),
+			"solidserver_app_node":            resourceapplicationnode(),
+			"solidserver_user":                resourceuser(),
+			"solidserver_usergroup":           resourceusergroup(),
+			"solidserver_cdb":                 resourcecdb(),
+			"solidserver_cdb_data":            resourcecdbdata(),
 		},
 		ConfigureContextFunc: ProviderConfigure,
 	}
@@ -116,6 +143,7 @@ func ProviderConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		d.Get("sslverify").(bool),
 		d.Get("additional_trust_certs_file").(string),
 		d.Get("solidserverversion").(string),
+		d.Get("protect_non_empty").(bool),
 	)
 	return s, err
 }
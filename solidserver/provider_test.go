@@ -2,15 +2,13 @@ package solidserver
 
 import (
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"log"
 	"os"
 	"testing"
 )
 
-var testProviders map[string]terraform.ResourceProvider
+var testProviders map[string]*schema.Provider
 var testProvider *schema.Provider
 
 func testAccPreCheck(t *testing.T) {
@@ -37,8 +35,8 @@ func init() {
 		fmt.Println("[WARN] use SOLIDServer_SSLVERIFY=false to bypass certificate validation")
 	}
 
-	testProvider = Provider().(*schema.Provider)
-	testProviders = map[string]terraform.ResourceProvider{
+	testProvider = Provider()
+	testProviders = map[string]*schema.Provider{
 		"solidserver": testProvider,
 	}
 }
@@ -68,6 +68,23 @@ func resourceapplication() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to application, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -274,6 +291,7 @@ func resourceapplicationRead(ctx context.Context, d *schema.ResourceData, meta i
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -342,6 +360,7 @@ func resourceapplicationImportState(ctx context.Context, d *schema.ResourceData,
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -53,6 +53,23 @@ func resourcedevice() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to device, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -220,6 +237,7 @@ func resourcedeviceRead(ctx context.Context, d *schema.ResourceData, meta interf
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -277,6 +295,7 @@ func resourcedeviceImportState(ctx context.Context, d *schema.ResourceData, meta
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -78,6 +78,23 @@ func resourcednsforwardzone() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the forward zone, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -285,6 +302,7 @@ func resourcednsforwardzoneRead(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -362,6 +380,7 @@ func resourcednsforwardzoneImportState(ctx context.Context, d *schema.ResourceDa
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return []*schema.ResourceData{d}, nil
 		}
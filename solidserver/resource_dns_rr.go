@@ -91,6 +91,23 @@ func resourcednsrr() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the RR, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -359,6 +376,7 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 					}
 				}
 				d.Set("class_parameters", computedClassParameters)
+				d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			}
 
 			return nil
@@ -436,6 +454,7 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 					}
 				}
 				d.Set("class_parameters", computedClassParameters)
+				d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			}
 
 			return []*schema.ResourceData{d}, nil
@@ -164,6 +164,23 @@ func resourcednsserver() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the DNS server, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -554,6 +571,7 @@ func resourcednsserverRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -668,6 +686,7 @@ func resourcednsserverImportState(ctx context.Context, d *schema.ResourceData, m
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			return []*schema.ResourceData{d}, nil
 		}
 
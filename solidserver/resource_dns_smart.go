@@ -115,6 +115,23 @@ func resourcednssmart() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the DNS SMART, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -449,6 +466,7 @@ func resourcednssmartRead(ctx context.Context, d *schema.ResourceData, meta inte
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -563,6 +581,7 @@ func resourcednssmartImportState(ctx context.Context, d *schema.ResourceData, me
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			return []*schema.ResourceData{d}, nil
 		}
 
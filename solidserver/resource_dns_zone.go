@@ -82,6 +82,13 @@ func resourcednszone() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the DNS zone while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the zone.",
@@ -98,6 +105,23 @@ func resourcednszone() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the zone, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -284,6 +308,13 @@ func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourcednszoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Refusing to delete a protected or non empty DNS zone, ignoring its own SOA and NS records
+	if err := deletionprotectioncheck(d, "DNS zone", map[string]string{
+		"dns_rr_count": "dnszone_id='" + d.Id() + "' AND rr_type!='SOA' AND NOT (rr_type='NS' AND rr_full_name='" + d.Get("name").(string) + "')",
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("dnszone_id", d.Id())
@@ -382,6 +413,7 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return nil
 		}
@@ -463,6 +495,7 @@ func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -0,0 +1,714 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/big"
+	"net/url"
+	"strconv"
+)
+
+func resourcedualstacksubnet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcedualstacksubnetCreate,
+		ReadContext:   resourcedualstacksubnetRead,
+		UpdateContext: resourcedualstacksubnetUpdate,
+		DeleteContext: resourcedualstacksubnetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcedualstacksubnetImportState,
+		},
+
+		Description: heredoc.Doc(`
+			Dual-stack subnet allows to allocate an IPv4 and an IPv6 terminal subnet for the same network segment.
+			Both subnets share the same name, class and class parameters and reference each other through their class parameters.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the subnets.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP block/subnet into which creating the IPv4 subnet.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"block6": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IPv6 block/subnet into which creating the IPv6 subnet.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv4 subnet address.",
+				ValidateFunc: validation.IsIPv4Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"request_ip6": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv6 subnet address.",
+				ValidateFunc: validation.IsIPv6Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The expected IPv4 subnet's prefix length (ex: 24 for a '/24').",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"prefix6_size": {
+				Type:         schema.TypeInt,
+				Description:  "The expected IPv6 subnet's prefix length (ex: 64 for a '/64').",
+				ValidateFunc: validation.IntBetween(1, 128),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"gateway_offset": {
+				Type:        schema.TypeInt,
+				Description: "Offset for creating the IPv4 gateway. Default is 0 (No gateway).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+			},
+			"gateway6_offset": {
+				Type:        schema.TypeInt,
+				Description: "Offset for creating the IPv6 gateway. Default is 0 (No gateway).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv4 and IPv6 subnets to create.",
+				Required:    true,
+				ForceNew:    false,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv4 and IPv6 subnets.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the IPv4 and IPv6 subnets.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv4 subnet, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters6_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv6 subnet, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all and class_parameters6_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the dual-stack subnet while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Description: "The oid of the IPv4 subnet.",
+				Computed:    true,
+			},
+			"subnet6_id": {
+				Type:        schema.TypeString,
+				Description: "The oid of the IPv6 subnet.",
+				Computed:    true,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv4 prefix.",
+				Computed:    true,
+			},
+			"prefix6": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 prefix.",
+				Computed:    true,
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Description: "The IPv4 subnet's computed gateway.",
+				Computed:    true,
+			},
+			"gateway6": {
+				Type:        schema.TypeString,
+				Description: "The IPv6 subnet's computed gateway.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Build the class parameters shared by both subnets of a dual-stack subnet
+// Including the gateway and the reference to the peer subnet
+func dualstackclassparameters(d *schema.ResourceData, gateway string, peerKey string, peerID string) url.Values {
+	classParameters := url.Values{}
+
+	if gateway != "" {
+		classParameters.Add("gateway", gateway)
+	}
+
+	if peerID != "" {
+		classParameters.Add(peerKey, peerID)
+	}
+
+	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+		classParameters.Add(k, v.(string))
+	}
+
+	return classParameters
+}
+
+// Allocate and register the IPv4 subnet of a dual-stack subnet
+// Return the oid, the network address and the gateway of the created subnet
+func dualstackipsubnetadd(d *schema.ResourceData, siteID string, meta interface{}) (string, string, string, error) {
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockInfo == nil || blockErr != nil {
+		return "", "", "", fmt.Errorf("Unable to find IP block: %s\n", d.Get("block").(string))
+	}
+
+	prefixSize := d.Get("prefix_size").(int)
+	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), prefixSize, meta)
+
+	if subnetErr != nil {
+		return "", "", "", subnetErr
+	}
+
+	subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
+
+	var lastErr error = fmt.Errorf("no free /%d prefix available in block: %s", prefixSize, d.Get("block").(string))
+
+	for _, hexAddr := range subnetAddresses {
+		address := hexiptoip(hexAddr)
+		gateway := ""
+
+		if goffset := d.Get("gateway_offset").(int); goffset > 0 {
+			gateway = longtoip(iptolong(address) + uint32(goffset))
+		} else if goffset < 0 {
+			gateway = longtoip(iptolong(address) + uint32(prefixlengthtosize(prefixSize)) - uint32(abs(goffset)) - 1)
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("subnet_name", d.Get("name").(string))
+		parameters.Add("subnet_addr", address)
+		parameters.Add("subnet_prefix", strconv.Itoa(prefixSize))
+		parameters.Add("subnet_class_name", d.Get("class").(string))
+		parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
+		parameters.Add("is_terminal", "1")
+		parameters.Add("subnet_class_parameters", dualstackclassparameters(d, gateway, "", "").Encode())
+
+		oid, err := objectadd("rest/ip_subnet_add", &parameters, meta)
+
+		if err == nil {
+			return oid, address, gateway, nil
+		}
+
+		lastErr = err
+	}
+
+	return "", "", "", fmt.Errorf("Unable to create IP subnet: %s (%s)\n", d.Get("name").(string), lastErr)
+}
+
+// Allocate and register the IPv6 subnet of a dual-stack subnet
+// Return the oid, the network address and the gateway of the created subnet
+func dualstackip6subnetadd(d *schema.ResourceData, siteID string, subnetID string, meta interface{}) (string, string, string, error) {
+	blockInfo, blockErr := ip6subnetinfobyname(siteID, d.Get("block6").(string), false, meta)
+
+	if blockInfo == nil || blockErr != nil {
+		return "", "", "", fmt.Errorf("Unable to find IPv6 block: %s\n", d.Get("block6").(string))
+	}
+
+	prefixSize := d.Get("prefix6_size").(int)
+	requestedIP := ""
+
+	if d.Get("request_ip6").(string) != "" {
+		requestedIP = shortip6tolongip6(d.Get("request_ip6").(string))
+	}
+
+	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), requestedIP, prefixSize, false, meta)
+
+	if subnetErr != nil {
+		return "", "", "", subnetErr
+	}
+
+	subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
+
+	var lastErr error = fmt.Errorf("no free /%d prefix available in block: %s", prefixSize, d.Get("block6").(string))
+
+	for _, hexAddr := range subnetAddresses {
+		start := hexiptobigint(hexAddr)
+		gateway := ""
+
+		if start == nil {
+			continue
+		}
+
+		if goffset := d.Get("gateway6_offset").(int); goffset > 0 {
+			gateway = bigintoip(new(big.Int).Add(start, big.NewInt(int64(goffset))), true)
+		} else if goffset < 0 {
+			end := new(big.Int).Add(start, prefix6lengthtosize(int64(prefixSize)))
+			gateway = bigintoip(end.Sub(end, big.NewInt(int64(abs(goffset)))), true)
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("subnet6_name", d.Get("name").(string))
+		parameters.Add("subnet6_addr", hexip6toip6(hexAddr))
+		parameters.Add("subnet6_prefix", strconv.Itoa(prefixSize))
+		parameters.Add("subnet6_class_name", d.Get("class").(string))
+		parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
+		parameters.Add("is_terminal", "1")
+		parameters.Add("subnet6_class_parameters", dualstackclassparameters(d, gateway, "dualstack_subnet_id", subnetID).Encode())
+
+		oid, err := objectadd("rest/ip6_subnet6_add", &parameters, meta)
+
+		if err == nil {
+			return oid, hexip6toip6(hexAddr), gateway, nil
+		}
+
+		lastErr = err
+	}
+
+	return "", "", "", fmt.Errorf("Unable to create IPv6 subnet: %s (%s)\n", d.Get("name").(string), lastErr)
+}
+
+// Update the name, class and class parameters of one of the subnets of a dual-stack subnet
+func dualstacksubnetedit(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "edit_only")
+
+	service := "rest/ip_subnet_add"
+
+	if v6 {
+		service = "rest/ip6_subnet6_add"
+		parameters.Add("subnet6_id", d.Get("subnet6_id").(string))
+		parameters.Add("subnet6_name", d.Get("name").(string))
+		parameters.Add("subnet6_class_name", d.Get("class").(string))
+		parameters.Add("subnet6_class_parameters", dualstackclassparameters(d, d.Get("gateway6").(string), "dualstack_subnet_id", d.Get("subnet_id").(string)).Encode())
+	} else {
+		parameters.Add("subnet_id", d.Get("subnet_id").(string))
+		parameters.Add("subnet_name", d.Get("name").(string))
+		parameters.Add("subnet_class_name", d.Get("class").(string))
+		parameters.Add("subnet_class_parameters", dualstackclassparameters(d, d.Get("gateway").(string), "dualstack_subnet6_id", d.Get("subnet6_id").(string)).Encode())
+	}
+
+	// Sending the update request
+	resp, body, err := s.Request("put", service, &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			return nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("Unable to update subnet: %s (%s)\n", d.Get("name").(string), errMsg)
+		}
+	}
+
+	return fmt.Errorf("Unable to update subnet: %s\n", d.Get("name").(string))
+}
+
+func resourcedualstacksubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetID, address, gateway, subnetErr := dualstackipsubnetadd(d, siteID, meta)
+
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", subnetID))
+
+	subnet6ID, address6, gateway6, subnet6Err := dualstackip6subnetadd(d, siteID, subnetID, meta)
+
+	if subnet6Err != nil {
+		// Rolling back the IPv4 subnet
+		parameters := url.Values{}
+		parameters.Add("subnet_id", subnetID)
+
+		if err := objectdelete("rest/ip_subnet_delete", &parameters, meta); err != nil {
+			return diag.Errorf("Unable to create dual-stack subnet: %s (%s), rollback of IP subnet (oid): %s failed (%s)\n", d.Get("name").(string), subnet6Err, subnetID, err)
+		}
+
+		// Reporting a failure
+		return diag.FromErr(subnet6Err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created IPv6 subnet (oid): %s\n", subnet6ID))
+
+	d.SetId(subnetID)
+	d.Set("subnet_id", subnetID)
+	d.Set("subnet6_id", subnet6ID)
+	d.Set("prefix", address+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+	d.Set("prefix6", address6+"/"+strconv.Itoa(d.Get("prefix6_size").(int)))
+	d.Set("gateway", gateway)
+	d.Set("gateway6", gateway6)
+
+	// Referencing the IPv6 subnet from the IPv4 one
+	if err := dualstacksubnetedit(d, false, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcedualstacksubnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := dualstacksubnetedit(d, false, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if err := dualstacksubnetedit(d, true, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated dual-stack subnet (oid): %s\n", d.Id()))
+
+	return nil
+}
+
+func resourcedualstacksubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subnetID := d.Get("subnet_id").(string)
+	subnet6ID := d.Get("subnet6_id").(string)
+
+	// Refusing to delete a protected or non empty dual-stack subnet, ignoring its own gateways
+	addressWhereClause := "subnet_id='" + subnetID + "' AND type='ip'"
+	address6WhereClause := "subnet6_id='" + subnet6ID + "' AND type='ip6'"
+
+	if d.Get("gateway").(string) != "" {
+		addressWhereClause += " AND ip_addr!='" + iptohexip(d.Get("gateway").(string)) + "'"
+	}
+
+	if d.Get("gateway6").(string) != "" {
+		address6WhereClause += " AND ip6_addr!='" + ip6tohexip6(shortip6tolongip6(d.Get("gateway6").(string))) + "'"
+	}
+
+	if err := deletionprotectioncheck(d, "dual-stack subnet", map[string]string{
+		"ip_block_subnet_count":    "parent_subnet_id='" + subnetID + "'",
+		"ip_pool_count":            "subnet_id='" + subnetID + "'",
+		"ip_address_count":         addressWhereClause,
+		"ip6_block6_subnet6_count": "parent_subnet6_id='" + subnet6ID + "'",
+		"ip6_pool6_count":          "subnet6_id='" + subnet6ID + "'",
+		"ip6_address6_count":       address6WhereClause,
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A part already deleted by a previous attempt is skipped, refreshing the state clears its oid
+	for _, part := range []struct {
+		v6      bool
+		oid     string
+		gateway string
+		oidKey  string
+		service string
+		kind    string
+	}{
+		{false, subnetID, d.Get("gateway").(string), "subnet_id", "rest/ip_subnet_delete", "IP"},
+		{true, subnet6ID, d.Get("gateway6").(string), "subnet6_id", "rest/ip6_subnet6_delete", "IPv6"},
+	} {
+		if part.oid == "" {
+			continue
+		}
+
+		if part.gateway != "" {
+			if err := subnetgatewayrelease(d.Get("space").(string), part.gateway, part.v6, meta); err != nil {
+				return diag.Errorf("Unable to delete %s subnet: %s (%s)\n", part.kind, d.Get("name").(string), err)
+			}
+		}
+
+		parameters := url.Values{}
+		parameters.Add(part.oidKey, part.oid)
+
+		if err := objectdelete(part.service, &parameters, meta); err != nil {
+			return diag.Errorf("Unable to delete %s subnet: %s (%s)\n", part.kind, d.Get("name").(string), err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleted %s subnet (oid): %s\n", part.kind, part.oid))
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted dual-stack subnet (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcedualstacksubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Both parts are compared to the state, a drift on either subnet is reported
+	stateName := d.Get("name").(string)
+	stateClass := d.Get("class").(string)
+	stateClassParameters := d.Get("class_parameters").(map[string]interface{})
+
+	computedName := stateName
+	computedClass := stateClass
+	computedClassParameters := map[string]string{}
+
+	for ck, cv := range stateClassParameters {
+		computedClassParameters[ck] = cv.(string)
+	}
+
+	for _, part := range []struct {
+		v6      bool
+		idKey   string
+		service string
+		prefix  string
+		allKey  string
+	}{
+		{false, "subnet_id", "rest/ip_block_subnet_info", "subnet", "class_parameters_all"},
+		{true, "subnet6_id", "rest/ip6_block6_subnet6_info", "subnet6", "class_parameters6_all"},
+	} {
+		// A part deleted by a previous attempt to destroy the dual-stack subnet
+		if d.Get(part.idKey).(string) == "" {
+			continue
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add(part.prefix+"_id", d.Get(part.idKey).(string))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", part.service, &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if resp.StatusCode != 200 || len(buf) == 0 {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find dual-stack subnet part (oid): %s\n", d.Get(part.idKey).(string)))
+
+			// The remaining part is kept, allowing to destroy it
+			d.Set(part.idKey, "")
+			continue
+		}
+
+		// Both subnets are expected to share the same name and class, reporting any drift
+		if name, nameExist := buf[0][part.prefix+"_name"].(string); nameExist && name != stateName {
+			computedName = name
+		}
+
+		if class, classExist := buf[0][part.prefix+"_class_name"].(string); classExist && class != stateClass {
+			computedClass = class
+		}
+
+		if part.v6 {
+			prefixSize, _ := strconv.Atoi(buf[0]["subnet6_prefix"].(string))
+			d.Set("prefix6", hexip6toip6(buf[0]["start_ip6_addr"].(string))+"/"+strconv.Itoa(prefixSize))
+		} else {
+			subnetSize, _ := strconv.Atoi(buf[0]["subnet_size"].(string))
+			d.Set("prefix", hexiptoip(buf[0]["start_ip_addr"].(string))+"/"+strconv.Itoa(sizetoprefixlength(subnetSize)))
+		}
+
+		retrievedClassParameters, _ := url.ParseQuery(buf[0][part.prefix+"_class_parameters"].(string))
+
+		// Updating local class_parameters
+		for ck, cv := range stateClassParameters {
+			if rv, rvExist := retrievedClassParameters[ck]; !rvExist {
+				computedClassParameters[ck] = ""
+			} else if rv[0] != cv.(string) {
+				computedClassParameters[ck] = rv[0]
+			}
+		}
+
+		d.Set(part.allKey, classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+		if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
+			if part.v6 {
+				d.Set("gateway6", gateway[0])
+			} else {
+				d.Set("gateway", gateway[0])
+			}
+		}
+	}
+
+	d.Set("name", computedName)
+	d.Set("class", computedClass)
+	d.Set("class_parameters", computedClassParameters)
+
+	// Both parts are gone
+	if d.Get("subnet_id").(string) == "" && d.Get("subnet6_id").(string) == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find dual-stack subnet (oid): %s\n", d.Id()))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+// Return the offset of a gateway within a subnet of the given size
+// Gateways standing in the upper half of the subnet are counted backward from its end (IPv4 broadcast address excluded)
+func dualstackgatewayoffset(start *big.Int, gateway *big.Int, size *big.Int, v6 bool) int {
+	offset := new(big.Int).Sub(gateway, start)
+
+	if offset.Cmp(new(big.Int).Rsh(size, 1)) < 0 {
+		return int(offset.Int64())
+	}
+
+	end := new(big.Int).Set(size)
+
+	if !v6 {
+		end.Sub(end, big.NewInt(1))
+	}
+
+	return -int(end.Sub(end, offset).Int64())
+}
+
+func resourcedualstacksubnetImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// The IPv4 subnet references its IPv6 peer
+	subnets, err := objectlistbyquery("rest/ip_block_subnet_list", "subnet_id='"+d.Id()+"' AND is_terminal='1'", "", "", 1, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	if len(subnets) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find and import dual-stack subnet (oid): %s\n", d.Id()))
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import dual-stack subnet (oid): %s\n", d.Id())
+	}
+
+	classParameters := entryclassparameters(subnets[0], "subnet_class_parameters")
+	subnet6ID := classParameters["dualstack_subnet6_id"]
+
+	if subnet6ID == "" {
+		return nil, fmt.Errorf("SOLIDServer - Unable to import dual-stack subnet (oid): %s, it doesn't reference any IPv6 subnet\n", d.Id())
+	}
+
+	subnets6, err := objectlistbyquery("rest/ip6_block6_subnet6_list", "subnet6_id='"+subnet6ID+"' AND is_terminal='1'", "", "", 1, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	if len(subnets6) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import dual-stack subnet (oid): %s IPv6 subnet (oid): %s\n", d.Id(), subnet6ID)
+	}
+
+	classParameters6 := entryclassparameters(subnets6[0], "subnet6_class_parameters")
+
+	subnetSize, _ := strconv.Atoi(entrystring(subnets[0], "subnet_size"))
+	prefixSize := sizetoprefixlength(subnetSize)
+	prefix6Size, _ := strconv.Atoi(entrystring(subnets6[0], "subnet6_prefix"))
+
+	d.Set("space", entrystring(subnets[0], "site_name"))
+	d.Set("block", entrystring(subnets[0], "parent_subnet_name"))
+	d.Set("block6", entrystring(subnets6[0], "parent_subnet6_name"))
+	d.Set("request_ip", "")
+	d.Set("request_ip6", "")
+	d.Set("prefix_size", prefixSize)
+	d.Set("prefix6_size", prefix6Size)
+	d.Set("name", entrystring(subnets[0], "subnet_name"))
+	d.Set("class", entrystring(subnets[0], "subnet_class_name"))
+	d.Set("subnet_id", d.Id())
+	d.Set("subnet6_id", subnet6ID)
+	d.Set("gateway", classParameters["gateway"])
+	d.Set("gateway6", classParameters6["gateway"])
+	d.Set("gateway_offset", 0)
+	d.Set("gateway6_offset", 0)
+
+	// The gateway offsets are not stored on SOLIDserver, computing them from the gateways
+	if gateway := classParameters["gateway"]; gateway != "" {
+		start := big.NewInt(int64(iptolong(hexiptoip(entrystring(subnets[0], "start_ip_addr")))))
+		d.Set("gateway_offset", dualstackgatewayoffset(start, big.NewInt(int64(iptolong(gateway))), big.NewInt(int64(subnetSize)), false))
+	}
+
+	if gateway6 := classParameters6["gateway"]; gateway6 != "" {
+		start := hexiptobigint(entrystring(subnets6[0], "start_ip6_addr"))
+		gateway := iptobigint(gateway6, true)
+
+		if start != nil && gateway != nil {
+			d.Set("gateway6_offset", dualstackgatewayoffset(start, gateway, prefix6lengthtosize(int64(prefix6Size)), true))
+		}
+	}
+
+	// The gateway and the reference to the peer subnet are not class parameters managed by terraform
+	delete(classParameters, "gateway")
+	delete(classParameters, "dualstack_subnet6_id")
+	d.Set("class_parameters", classParameters)
+
+	if diags := resourcedualstacksubnetRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("SOLIDServer - Unable to import dual-stack subnet (oid): %s\n", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
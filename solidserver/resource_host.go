@@ -0,0 +1,953 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func resourcehost() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcehostCreate,
+		ReadContext:   resourcehostRead,
+		UpdateContext: resourcehostUpdate,
+		DeleteContext: resourcehostDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcehostImportState,
+		},
+
+		Description: heredoc.Doc(`
+			Host resource allows to register a host as a single unit: its IPv4 and/or IPv6 addresses,
+			along with the related forward (A/AAAA), reverse (PTR) and alias (CNAME) DNS records.
+			Every part created is rolled back if any step of the registration fails.
+			DNS records existing beforehand are adopted, they are left in place when no longer expected or when the host is destroyed.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the host addresses.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:         schema.TypeString,
+				Description:  "The name of the IPv4 subnet into which allocating the host IPv4 address.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+				AtLeastOneOf: []string{"subnet", "subnet6"},
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv4 address.",
+				ValidateFunc: validation.IsIPv4Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"subnet6": {
+				Type:         schema.TypeString,
+				Description:  "The name of the IPv6 subnet into which allocating the host IPv6 address.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+				AtLeastOneOf: []string{"subnet", "subnet6"},
+			},
+			"request_ip6": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv6 address.",
+				ValidateFunc: validation.IsIPv6Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The Fully Qualified Domain Name of the host.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name hosting the host's records.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The View name of the host's records.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"forward": {
+				Type:        schema.TypeBool,
+				Description: "Create the forward A/AAAA records of the host (Default: true).",
+				Optional:    true,
+				Default:     true,
+			},
+			"reverse": {
+				Type:        schema.TypeBool,
+				Description: "Create the reverse PTR records of the host (Default: true).",
+				Optional:    true,
+				Default:     true,
+			},
+			"aliases": {
+				Type:        schema.TypeSet,
+				Description: "The Fully Qualified Domain Names of the CNAME records pointing to the host.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Description: "The DNS Time To Live of the host's records.",
+				Optional:    true,
+				Default:     3600,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv4 address.",
+				Computed:    true,
+			},
+			"address6": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 address.",
+				Computed:    true,
+			},
+			"address_id": {
+				Type:        schema.TypeString,
+				Description: "The oid of the provisionned IPv4 address.",
+				Computed:    true,
+			},
+			"address6_id": {
+				Type:        schema.TypeString,
+				Description: "The oid of the provisionned IPv6 address.",
+				Computed:    true,
+			},
+			"adopted_records": {
+				Type:        schema.TypeSet,
+				Description: "The DNS records (name, type and value) existing before being expected by the host, they are never deleted by terraform.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Return the oid of one of the host's RR
+// Or an empty string if the RR can't be found
+func hostrridbyinfo(d *schema.ResourceData, rrName string, rrType string, rrValue string, meta interface{}) (string, error) {
+	rr, err := hostrrbyinfo(d, rrName, rrType, rrValue, meta)
+
+	if err != nil || rr == nil {
+		return "", err
+	}
+
+	return entrystring(rr, "rr_id"), nil
+}
+
+// Return one of the host's RR
+// Or nil if the RR can't be found
+func hostrrbyinfo(d *schema.ResourceData, rrName string, rrType string, rrValue string, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	whereClause := "dns_name='" + d.Get("dnsserver").(string) + "' AND rr_full_name='" + rrName + "' AND rr_type='" + rrType + "' AND value1='" + rrValue + "'"
+
+	if len(d.Get("dnsview").(string)) != 0 {
+		whereClause += " AND dnsview_name='" + d.Get("dnsview").(string) + "'"
+	} else {
+		whereClause += " AND dnsview_name='#'"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_rr_list", &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		if _, rrIDExist := buf[0]["rr_id"].(string); rrIDExist {
+			return buf[0], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Create or update one of the host's RR with the given TTL
+// Return the previous TTL of the RR, or an empty string if the RR was created
+func hostrrset(d *schema.ResourceData, rrName string, rrType string, rrValue string, ttl string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	rr, rrErr := hostrrbyinfo(d, rrName, rrType, rrValue, meta)
+
+	if rrErr != nil {
+		return "", rrErr
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("dns_name", d.Get("dnsserver").(string))
+	parameters.Add("rr_name", rrName)
+	parameters.Add("rr_type", rrType)
+	parameters.Add("value1", rrValue)
+	parameters.Add("rr_ttl", ttl)
+
+	// Add dnsview parameter if it is supplied
+	if len(d.Get("dnsview").(string)) != 0 {
+		parameters.Add("dnsview_name", strings.ToLower(d.Get("dnsview").(string)))
+	}
+
+	if rr == nil {
+		parameters.Add("add_flag", "new_only")
+
+		if _, err := objectadd("rest/dns_rr_add", &parameters, meta); err != nil {
+			return "", fmt.Errorf("Unable to create RR: %s - %s (%s)\n", rrName, rrType, err)
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Created host RR: %s - %s\n", rrName, rrType))
+
+		return "", nil
+	}
+
+	previousTTL := entrystring(rr, "ttl")
+
+	parameters.Add("rr_id", entrystring(rr, "rr_id"))
+	parameters.Add("add_flag", "edit_only")
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/dns_rr_add", &parameters)
+
+	if err != nil {
+		return previousTTL, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Updated host RR: %s - %s\n", rrName, rrType))
+		return previousTTL, nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return previousTTL, fmt.Errorf("Unable to update RR: %s - %s (%s)\n", rrName, rrType, errMsg)
+		}
+	}
+
+	return previousTTL, fmt.Errorf("Unable to update RR: %s - %s\n", rrName, rrType)
+}
+
+// Delete one of the host's RR, if it exists
+func hostrrdelete(d *schema.ResourceData, rrName string, rrType string, rrValue string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	rrID, rrErr := hostrridbyinfo(d, rrName, rrType, rrValue, meta)
+
+	if rrErr != nil {
+		return rrErr
+	}
+
+	if rrID == "" {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Host RR: %s - %s already deleted\n", rrName, rrType))
+		return nil
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("rr_id", rrID)
+
+	// Add dnsview parameter if it is supplied
+	if len(d.Get("dnsview").(string)) != 0 {
+		parameters.Add("dnsview_name", strings.ToLower(d.Get("dnsview").(string)))
+	}
+
+	if err := objectdelete("rest/dns_rr_delete", &parameters, meta); err != nil {
+		return fmt.Errorf("Unable to delete RR: %s - %s (%s)\n", rrName, rrType, err)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Deleted host RR: %s - %s\n", rrName, rrType))
+
+	return nil
+}
+
+// Return the key identifying one of the host's RR within its adopted records
+func hostrrkey(rrName string, rrType string, rrValue string) string {
+	return rrName + " " + rrType + " " + rrValue
+}
+
+// Delete one of the host's RR, unless it was adopted
+// The RR is no longer expected by the host, it is removed from the adopted records
+func hostrrrelease(d *schema.ResourceData, adopted map[string]bool, rrName string, rrType string, rrValue string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	if key := hostrrkey(rrName, rrType, rrValue); adopted[key] {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Keeping adopted host RR: %s - %s\n", rrName, rrType))
+		delete(adopted, key)
+		return nil
+	}
+
+	return hostrrdelete(d, rrName, rrType, rrValue, meta)
+}
+
+// Return the adopted records of the host
+func hostadoptedrecords(d *schema.ResourceData) map[string]bool {
+	adopted := map[string]bool{}
+
+	for _, key := range d.Get("adopted_records").(*schema.Set).List() {
+		adopted[key.(string)] = true
+	}
+
+	return adopted
+}
+
+// Store the adopted records of the host
+func hostadoptedrecordsset(d *schema.ResourceData, adopted map[string]bool) {
+	keys := []interface{}{}
+
+	for key := range adopted {
+		keys = append(keys, key)
+	}
+
+	d.Set("adopted_records", keys)
+}
+
+// Return the forward records (type and value) of the host
+func hostforwardrecords(d *schema.ResourceData) [][2]string {
+	records := [][2]string{}
+
+	if d.Get("address").(string) != "" {
+		records = append(records, [2]string{"A", d.Get("address").(string)})
+	}
+
+	if d.Get("address6").(string) != "" {
+		records = append(records, [2]string{"AAAA", shortip6tolongip6(d.Get("address6").(string))})
+	}
+
+	return records
+}
+
+// Return the reverse records (name and value) of the host
+func hostreverserecords(d *schema.ResourceData) [][2]string {
+	records := [][2]string{}
+
+	if d.Get("address").(string) != "" {
+		records = append(records, [2]string{iptoptr(d.Get("address").(string)), d.Get("name").(string)})
+	}
+
+	if d.Get("address6").(string) != "" {
+		records = append(records, [2]string{ip6toptr(shortip6tolongip6(d.Get("address6").(string))), d.Get("name").(string)})
+	}
+
+	return records
+}
+
+// Create every record of the host according to its configuration
+// Return the rollback actions deleting the records created and restoring the ones adopted
+func hostrecordscreate(d *schema.ResourceData, adopted map[string]bool, meta interface{}) ([]func() error, error) {
+	rollback := []func() error{}
+	name := d.Get("name").(string)
+	ttl := strconv.Itoa(d.Get("ttl").(int))
+
+	records := [][3]string{}
+
+	if d.Get("forward").(bool) {
+		for _, r := range hostforwardrecords(d) {
+			records = append(records, [3]string{name, r[0], r[1]})
+		}
+	}
+
+	if d.Get("reverse").(bool) {
+		for _, r := range hostreverserecords(d) {
+			records = append(records, [3]string{r[0], "PTR", r[1]})
+		}
+	}
+
+	for _, alias := range d.Get("aliases").(*schema.Set).List() {
+		records = append(records, [3]string{alias.(string), "CNAME", name})
+	}
+
+	for _, r := range records {
+		record := r
+
+		previousTTL, err := hostrrset(d, record[0], record[1], record[2], ttl, meta)
+
+		if err != nil {
+			return rollback, err
+		}
+
+		// Records existing beforehand are adopted, they are left in place with their previous TTL
+		if previousTTL != "" {
+			adopted[hostrrkey(record[0], record[1], record[2])] = true
+
+			rollback = append(rollback, func() error {
+				_, err := hostrrset(d, record[0], record[1], record[2], previousTTL, meta)
+				return err
+			})
+			continue
+		}
+
+		rollback = append(rollback, func() error {
+			return hostrrdelete(d, record[0], record[1], record[2], meta)
+		})
+	}
+
+	return rollback, nil
+}
+
+// Allocate and register one of the host's addresses
+// Return the oid and the value of the address created
+func hostaddressadd(d *schema.ResourceData, siteID string, v6 bool, meta interface{}) (string, string, error) {
+	var candidates []string = nil
+	var subnetName string = d.Get("subnet").(string)
+	var requestedIP string = d.Get("request_ip").(string)
+	var service string = "rest/ip_add"
+	var prefix string = "ip"
+
+	if v6 {
+		subnetName = d.Get("subnet6").(string)
+		requestedIP = d.Get("request_ip6").(string)
+		service = "rest/ip6_address6_add"
+		prefix = "ip6"
+	}
+
+	if len(requestedIP) > 0 {
+		candidates = []string{requestedIP}
+	} else if v6 {
+		subnetInfo, subnetErr := ip6subnetinfobyname(siteID, subnetName, true, meta)
+
+		if subnetInfo == nil || subnetErr != nil {
+			return "", "", fmt.Errorf("Unable to find IPv6 subnet: %s\n", subnetName)
+		}
+
+		candidates, _ = ip6addressfindfree(subnetInfo["id"].(string), "", meta)
+	} else {
+		subnetInfo, subnetErr := ipsubnetinfobyname(siteID, subnetName, true, meta)
+
+		if subnetInfo == nil || subnetErr != nil {
+			return "", "", fmt.Errorf("Unable to find IP subnet: %s\n", subnetName)
+		}
+
+		candidates, _ = ipaddressfindfree(subnetInfo["id"].(string), "", meta)
+	}
+
+	var lastErr error = fmt.Errorf("no free address available in subnet: %s", subnetName)
+
+	for _, candidate := range candidates {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add(prefix+"_name", d.Get("name").(string))
+		parameters.Add("hostaddr", candidate)
+
+		oid, err := objectadd(service, &parameters, meta)
+
+		if err == nil {
+			return oid, candidate, nil
+		}
+
+		lastErr = err
+	}
+
+	return "", "", fmt.Errorf("Unable to create host address in subnet: %s (%s)\n", subnetName, lastErr)
+}
+
+// Run the rollback actions in reverse order
+func hostrollback(ctx context.Context, rollback []func() error) {
+	for i := len(rollback) - 1; i >= 0; i-- {
+		if err := rollback[i](); err != nil {
+			tflog.Error(ctx, fmt.Sprintf("Host rollback failed: %s\n", err))
+		}
+	}
+}
+
+func resourcehostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rollback := []func() error{}
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	if len(d.Get("subnet").(string)) > 0 {
+		oid, address, err := hostaddressadd(d, siteID, false, meta)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create host: %s (%s)\n", d.Get("name").(string), err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Created host IP address (oid): %s\n", oid))
+		d.Set("address", address)
+		d.Set("address_id", oid)
+
+		rollback = append(rollback, func() error {
+			parameters := url.Values{}
+			parameters.Add("ip_id", oid)
+			return objectdelete("rest/ip_delete", &parameters, meta)
+		})
+	}
+
+	if len(d.Get("subnet6").(string)) > 0 {
+		oid, address, err := hostaddressadd(d, siteID, true, meta)
+
+		if err != nil {
+			// Rolling back the parts already created
+			hostrollback(ctx, rollback)
+
+			// Reporting a failure
+			return diag.Errorf("Unable to create host: %s (%s)\n", d.Get("name").(string), err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Created host IPv6 address (oid): %s\n", oid))
+		d.Set("address6", longip6toshortip6(address))
+		d.Set("address6_id", oid)
+
+		rollback = append(rollback, func() error {
+			parameters := url.Values{}
+			parameters.Add("ip6_id", oid)
+			return objectdelete("rest/ip6_address6_delete", &parameters, meta)
+		})
+	}
+
+	adopted := map[string]bool{}
+	recordsRollback, recordsErr := hostrecordscreate(d, adopted, meta)
+
+	if recordsErr != nil {
+		// Rolling back the parts already created
+		hostrollback(ctx, append(rollback, recordsRollback...))
+
+		// Reporting a failure
+		return diag.Errorf("Unable to create host: %s (%s)\n", d.Get("name").(string), recordsErr)
+	}
+
+	hostadoptedrecordsset(d, adopted)
+
+	if d.Get("address_id").(string) != "" {
+		d.SetId(d.Get("address_id").(string))
+	} else {
+		d.SetId(d.Get("address6_id").(string))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created host (oid): %s\n", d.Id()))
+
+	return nil
+}
+
+func resourcehostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	adopted := hostadoptedrecords(d)
+
+	// Keeping track of the adopted records, including on failure
+	defer hostadoptedrecordsset(d, adopted)
+
+	// Forward records
+	if d.HasChange("forward") || (d.HasChange("ttl") && d.Get("forward").(bool)) {
+		for _, r := range hostforwardrecords(d) {
+			var err error = nil
+
+			if d.Get("forward").(bool) {
+				var previousTTL string
+
+				previousTTL, err = hostrrset(d, name, r[0], r[1], strconv.Itoa(d.Get("ttl").(int)), meta)
+
+				// Records existing before being expected are adopted
+				if err == nil && previousTTL != "" && d.HasChange("forward") {
+					adopted[hostrrkey(name, r[0], r[1])] = true
+				}
+			} else {
+				err = hostrrrelease(d, adopted, name, r[0], r[1], meta)
+			}
+
+			if err != nil {
+				return diag.Errorf("Unable to update host: %s (%s)\n", name, err)
+			}
+		}
+	}
+
+	// Reverse records
+	if d.HasChange("reverse") || (d.HasChange("ttl") && d.Get("reverse").(bool)) {
+		for _, r := range hostreverserecords(d) {
+			var err error = nil
+
+			if d.Get("reverse").(bool) {
+				var previousTTL string
+
+				previousTTL, err = hostrrset(d, r[0], "PTR", r[1], strconv.Itoa(d.Get("ttl").(int)), meta)
+
+				// Records existing before being expected are adopted
+				if err == nil && previousTTL != "" && d.HasChange("reverse") {
+					adopted[hostrrkey(r[0], "PTR", r[1])] = true
+				}
+			} else {
+				err = hostrrrelease(d, adopted, r[0], "PTR", r[1], meta)
+			}
+
+			if err != nil {
+				return diag.Errorf("Unable to update host: %s (%s)\n", name, err)
+			}
+		}
+	}
+
+	// Alias records
+	if d.HasChanges("aliases", "ttl") {
+		oldAliases, newAliases := d.GetChange("aliases")
+
+		for _, alias := range oldAliases.(*schema.Set).Difference(newAliases.(*schema.Set)).List() {
+			if err := hostrrrelease(d, adopted, alias.(string), "CNAME", name, meta); err != nil {
+				return diag.Errorf("Unable to update host: %s (%s)\n", name, err)
+			}
+		}
+
+		for _, alias := range newAliases.(*schema.Set).List() {
+			if !d.HasChange("ttl") && oldAliases.(*schema.Set).Contains(alias) {
+				continue
+			}
+
+			previousTTL, err := hostrrset(d, alias.(string), "CNAME", name, strconv.Itoa(d.Get("ttl").(int)), meta)
+
+			if err != nil {
+				return diag.Errorf("Unable to update host: %s (%s)\n", name, err)
+			}
+
+			// Records existing before being expected are adopted
+			if previousTTL != "" && !oldAliases.(*schema.Set).Contains(alias) {
+				adopted[hostrrkey(alias.(string), "CNAME", name)] = true
+			}
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated host (oid): %s\n", d.Id()))
+
+	return resourcehostRead(ctx, d, meta)
+}
+
+func resourcehostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	adopted := hostadoptedrecords(d)
+
+	for _, alias := range d.Get("aliases").(*schema.Set).List() {
+		if err := hostrrrelease(d, adopted, alias.(string), "CNAME", name, meta); err != nil {
+			return diag.Errorf("Unable to delete host: %s (%s)\n", name, err)
+		}
+	}
+
+	for _, r := range hostreverserecords(d) {
+		if err := hostrrrelease(d, adopted, r[0], "PTR", r[1], meta); err != nil {
+			return diag.Errorf("Unable to delete host: %s (%s)\n", name, err)
+		}
+	}
+
+	for _, r := range hostforwardrecords(d) {
+		if err := hostrrrelease(d, adopted, name, r[0], r[1], meta); err != nil {
+			return diag.Errorf("Unable to delete host: %s (%s)\n", name, err)
+		}
+	}
+
+	if oid := d.Get("address6_id").(string); oid != "" {
+		parameters := url.Values{}
+		parameters.Add("ip6_id", oid)
+
+		if err := objectdelete("rest/ip6_address6_delete", &parameters, meta); err != nil {
+			return diag.Errorf("Unable to delete host: %s IPv6 address (%s)\n", name, err)
+		}
+	}
+
+	if oid := d.Get("address_id").(string); oid != "" {
+		parameters := url.Values{}
+		parameters.Add("ip_id", oid)
+
+		if err := objectdelete("rest/ip_delete", &parameters, meta); err != nil {
+			return diag.Errorf("Unable to delete host: %s IP address (%s)\n", name, err)
+		}
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted host (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcehostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	name := d.Get("name").(string)
+
+	// Reading the host's addresses
+	for _, part := range []struct {
+		idKey   string
+		service string
+		oidKey  string
+		addrKey string
+		nameKey string
+		v6      bool
+	}{
+		{"address_id", "rest/ip_address_info", "ip_id", "ip_addr", "name", false},
+		{"address6_id", "rest/ip6_address6_info", "ip6_id", "ip6_addr", "ip6_name", true},
+	} {
+		oid := d.Get(part.idKey).(string)
+
+		if oid == "" {
+			continue
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add(part.oidKey, oid)
+
+		// Sending the read request
+		resp, body, err := s.Request("get", part.service, &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if resp.StatusCode != 200 || len(buf) == 0 {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find host address (oid): %s\n", oid))
+
+			// Do not unset the local ID to avoid inconsistency
+
+			// Reporting a failure
+			return diag.Errorf("Unable to find host: %s address (oid): %s\n", name, oid)
+		}
+
+		if part.v6 {
+			d.Set("address6", longip6toshortip6(hexip6toip6(buf[0][part.addrKey].(string))))
+		} else {
+			d.Set("address", hexiptoip(buf[0][part.addrKey].(string)))
+		}
+
+		// A renamed address means the host is no longer consistent
+		if addrName, addrNameExist := buf[0][part.nameKey].(string); addrNameExist && addrName != name {
+			d.Set("name", addrName)
+		}
+	}
+
+	// Reading the host's records, any missing one is reported as a drift
+	if d.Get("forward").(bool) {
+		for _, r := range hostforwardrecords(d) {
+			if rrID, _ := hostrridbyinfo(d, name, r[0], r[1], meta); rrID == "" {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find host: %s %s record\n", name, r[0]))
+				d.Set("forward", false)
+			}
+		}
+	}
+
+	if d.Get("reverse").(bool) {
+		for _, r := range hostreverserecords(d) {
+			if rrID, _ := hostrridbyinfo(d, r[0], "PTR", r[1], meta); rrID == "" {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find host: %s PTR record: %s\n", name, r[0]))
+				d.Set("reverse", false)
+			}
+		}
+	}
+
+	aliases := []interface{}{}
+
+	for _, alias := range d.Get("aliases").(*schema.Set).List() {
+		if rrID, _ := hostrridbyinfo(d, alias.(string), "CNAME", name, meta); rrID != "" {
+			aliases = append(aliases, alias)
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find host: %s CNAME record: %s\n", name, alias.(string)))
+		}
+	}
+
+	d.Set("aliases", aliases)
+
+	return nil
+}
+
+// Import a host from the oid of its IPv4 address, or of its IPv6 address if there is none
+// The other address and the DNS records are found through the host name
+func resourcehostImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	var address map[string]interface{} = nil
+	v6 := false
+
+	for _, part := range []struct {
+		service string
+		oidKey  string
+		v6      bool
+	}{
+		{"rest/ip_address_info", "ip_id", false},
+		{"rest/ip6_address6_info", "ip6_id", true},
+	} {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add(part.oidKey, d.Id())
+
+		// Sending the read request
+		resp, body, err := s.Request("get", part.service, &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return nil, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if _, oidExist := buf[0][part.oidKey].(string); oidExist {
+				address, v6 = buf[0], part.v6
+				break
+			}
+		}
+	}
+
+	if address == nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find and import host (oid): %s\n", d.Id()))
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import host (oid): %s\n", d.Id())
+	}
+
+	space := entrystring(address, "site_name")
+	name := entrystring(address, "name")
+	whereClause := "site_name='" + space + "' AND ip6_name='" + name + "' AND type='ip6'"
+	service, oidKey, addrKey, subnetKey := "rest/ip6_address6_list", "ip6_id", "ip6_addr", "subnet6_name"
+
+	d.Set("space", space)
+	d.Set("request_ip", "")
+	d.Set("request_ip6", "")
+
+	if v6 {
+		name = entrystring(address, "ip6_name")
+		whereClause = "site_name='" + space + "' AND name='" + name + "' AND type='ip'"
+		service, oidKey, addrKey, subnetKey = "rest/ip_address_list", "ip_id", "ip_addr", "subnet_name"
+
+		d.Set("subnet6", entrystring(address, "subnet6_name"))
+		d.Set("address6", longip6toshortip6(hexip6toip6(entrystring(address, "ip6_addr"))))
+		d.Set("address6_id", d.Id())
+	} else {
+		d.Set("subnet", entrystring(address, "subnet_name"))
+		d.Set("address", hexiptoip(entrystring(address, "ip_addr")))
+		d.Set("address_id", d.Id())
+	}
+
+	d.Set("name", name)
+
+	// Looking for the address of the other family registered under the same name
+	others, err := objectlistbyquery(service, whereClause, "", "", 1, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	if len(others) > 0 {
+		if v6 {
+			d.Set("subnet", entrystring(others[0], subnetKey))
+			d.Set("address", hexiptoip(entrystring(others[0], addrKey)))
+			d.Set("address_id", entrystring(others[0], oidKey))
+		} else {
+			d.Set("subnet6", entrystring(others[0], subnetKey))
+			d.Set("address6", longip6toshortip6(hexip6toip6(entrystring(others[0], addrKey))))
+			d.Set("address6_id", entrystring(others[0], oidKey))
+		}
+	}
+
+	// Looking for the forward record to find out the DNS server and view
+	forward := hostforwardrecords(d)[0]
+	records, err := objectlistbyquery("rest/dns_rr_list", "rr_full_name='"+name+"' AND rr_type='"+forward[0]+"' AND value1='"+forward[1]+"'", "", "", 1, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find and import host: %s %s record\n", name, forward[0]))
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import host: %s %s record\n", name, forward[0])
+	}
+
+	ttl, _ := strconv.Atoi(entrystring(records[0], "ttl"))
+
+	d.Set("dnsserver", entrystring(records[0], "dns_name"))
+	d.Set("ttl", ttl)
+	d.Set("forward", true)
+
+	if dnsview := entrystring(records[0], "dnsview_name"); dnsview != "#" {
+		d.Set("dnsview", dnsview)
+	} else {
+		d.Set("dnsview", "")
+	}
+
+	// Reading the reverse and alias records
+	reverse := true
+
+	for _, r := range hostreverserecords(d) {
+		if rrID, _ := hostrridbyinfo(d, r[0], "PTR", r[1], meta); rrID == "" {
+			reverse = false
+		}
+	}
+
+	d.Set("reverse", reverse)
+
+	aliasesClause := "dns_name='" + d.Get("dnsserver").(string) + "' AND rr_type='CNAME' AND value1='" + name + "'"
+
+	if len(d.Get("dnsview").(string)) != 0 {
+		aliasesClause += " AND dnsview_name='" + d.Get("dnsview").(string) + "'"
+	} else {
+		aliasesClause += " AND dnsview_name='#'"
+	}
+
+	cnames, err := objectlistbyquery("rest/dns_rr_list", aliasesClause, "", "", 0, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	aliases := []interface{}{}
+
+	for _, cname := range cnames {
+		aliases = append(aliases, entrystring(cname, "rr_full_name"))
+	}
+
+	d.Set("aliases", aliases)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -20,6 +20,7 @@ func resourceip6address() *schema.Resource {
 		ReadContext:   resourceip6addressRead,
 		UpdateContext: resourceip6addressUpdate,
 		DeleteContext: resourceip6addressDelete,
+		CustomizeDiff: resourceip6addressCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceip6addressImportState,
 		},
@@ -57,6 +58,37 @@ func resourceip6address() *schema.Resource {
 				ForceNew:     true,
 				Default:      "",
 			},
+			"eui64_from_mac": {
+				Type:          schema.TypeBool,
+				Description:   "Derive the IPv6 address from the subnet prefix and the MAC address (modified EUI-64), as configured by SLAAC hosts (Default: false).",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"request_ip"},
+				Default:       false,
+			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the IPv6 address when none is requested: first, last, random or offset (Default: first). Only used when allocating the IPv6 address.",
+				ValidateFunc: validation.StringInSlice([]string{"first", "last", "random", "offset"}, false),
+				Optional:     true,
+				Default:      "first",
+			},
+			"allocation_offset": {
+				Type:         schema.TypeInt,
+				Description:  "The offset from the start of the subnet of the IPv6 address to allocate, only supported by the offset allocation strategy. Only used when allocating the IPv6 address.",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "The list of addresses, offsets from the start of the subnet or ranges of them (a-b) never to be allocated. Only used when allocating the IPv6 address.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: resourceipallocationexcludevalidate,
+				},
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Description: "The provisionned IPv6 address.",
@@ -85,6 +117,36 @@ func resourceip6address() *schema.Resource {
 				DiffSuppressFunc: resourcediffsuppresscase,
 				Default:          "",
 			},
+			"aliases": {
+				Type:          schema.TypeSet,
+				Description:   "The aliases (CNAME or A) of the IPv6 address, managed as a whole: aliases missing from the set are removed. Conflicts with solidserver_ip6_alias resources targeting the same address, use ignore_aliases along with them.",
+				Optional:      true,
+				ForceNew:      false,
+				ConflictsWith: []string{"ignore_aliases"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The FQDN of the alias.",
+							Required:    true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Description:  "The type of the alias (Supported: A, CNAME; Default: CNAME).",
+							ValidateFunc: validation.StringInSlice([]string{"A", "CNAME"}, false),
+							Optional:     true,
+							Default:      "CNAME",
+						},
+					},
+				},
+			},
+			"ignore_aliases": {
+				Type:        schema.TypeBool,
+				Description: "Leave the aliases of the IPv6 address untouched, when they are managed through solidserver_ip6_alias resources (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IPv6 address.",
@@ -102,14 +164,132 @@ func resourceip6address() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv6 address, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IPv6 address, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested IPv6 address fits within the subnet and the pool
+func resourceip6addressCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The allocation offset only applies to the offset allocation strategy
+	if d.NewValueKnown("allocation_offset") && d.NewValueKnown("allocation_strategy") && d.Get("allocation_offset").(int) != 0 && d.Get("allocation_strategy").(string) != "offset" {
+		return fmt.Errorf("Unable to create IPv6 address: %s, allocation_offset requires the offset allocation_strategy\n", d.Get("name").(string))
+	}
+
+	// Only check new or moving IPv6 addresses, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "subnet", "pool", "request_ip", "eui64_from_mac", "mac") {
+		return nil
+	}
+
+	if d.Get("eui64_from_mac").(bool) {
+		if !d.NewValueKnown("mac") {
+			return nil
+		}
+
+		if len(d.Get("mac").(string)) == 0 {
+			return fmt.Errorf("Unable to create IPv6 address: %s, eui64_from_mac requires a MAC address\n", d.Get("name").(string))
+		}
+
+		// The address is derived from the MAC address, changing it moves the IPv6 address
+		if d.Id() != "" && d.HasChange("mac") {
+			if err := d.ForceNew("mac"); err != nil {
+				return err
+			}
+		}
+	} else if d.Id() != "" && !d.HasChanges("space", "subnet", "pool", "request_ip") {
+		return nil
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("subnet") || !d.NewValueKnown("pool") || !d.NewValueKnown("request_ip") {
+		return nil
+	}
+
+	if len(d.Get("request_ip").(string)) == 0 && !d.Get("eui64_from_mac").(bool) {
+		return nil
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 address: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	subnetInfo, subnetErr := ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil || subnetInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 address: %s, unable to find subnet: %s\n", d.Get("name").(string), d.Get("subnet").(string)))
+		return nil
+	}
+
+	requestedIP := d.Get("request_ip").(string)
+
+	if d.Get("eui64_from_mac").(bool) {
+		var eui64Err error = nil
+
+		requestedIP, eui64Err = ip6eui64address(subnetInfo, d.Get("mac").(string))
+
+		if eui64Err != nil {
+			return eui64Err
+		}
+	}
+
+	requestedHexIP := ip6tohexip6(shortip6tolongip6(requestedIP))
+
+	if strings.Compare(subnetInfo["start_hex_addr"].(string), requestedHexIP) != -1 ||
+		strings.Compare(requestedHexIP, subnetInfo["end_hex_addr"].(string)) != -1 {
+		return fmt.Errorf("Unable to create IPv6 address: %s, address %s is out of network's range (%s - %s)\n", d.Get("name").(string), requestedIP, hexip6toip6(subnetInfo["start_hex_addr"].(string)), hexip6toip6(subnetInfo["end_hex_addr"].(string)))
+	}
+
+	if len(d.Get("pool").(string)) > 0 {
+		poolInfo, poolErr := ip6poolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+
+		if poolErr != nil || poolInfo == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 address: %s, unable to find pool: %s\n", d.Get("name").(string), d.Get("pool").(string)))
+			return nil
+		}
+
+		if strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
+			strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1 {
+			return fmt.Errorf("Unable to create IPv6 address: %s, address %s is out of pool's range (%s - %s)\n", d.Get("name").(string), requestedIP, hexip6toip6(poolInfo["start_hex_addr"].(string)), hexip6toip6(poolInfo["end_hex_addr"].(string)))
+		}
+	}
+
+	return nil
+}
+
 func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	var requestedHexIP string = ip6tohexip6(d.Get("request_ip").(string))
+	var requestedIP string = d.Get("request_ip").(string)
+	var requestedHexIP string = ""
 	var poolInfo map[string]interface{} = nil
 	var ipAddresses []string = nil
 	var deviceID string = ""
@@ -153,8 +333,22 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	// Deriving the IPv6 address from the subnet prefix and the MAC address as SLAAC hosts do
+	if d.Get("eui64_from_mac").(bool) {
+		var eui64Err error = nil
+
+		requestedIP, eui64Err = ip6eui64address(subnetInfo, d.Get("mac").(string))
+
+		if eui64Err != nil {
+			// Reporting a failure
+			return diag.FromErr(eui64Err)
+		}
+	}
+
+	requestedHexIP = ip6tohexip6(requestedIP)
+
 	// Determining if an IP address was submitted in or if we should get one from the IPAM
-	if len(d.Get("request_ip").(string)) > 0 {
+	if len(requestedIP) > 0 {
 		// Ensure IP Address is within the given subnet start and end IP addresses
 		if strings.Compare(subnetInfo["terminal"].(string), "1") == 0 &&
 			strings.Compare(subnetInfo["start_hex_addr"].(string), requestedHexIP) == -1 &&
@@ -165,7 +359,7 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 				return diag.Errorf("Unable to create IPv6 address: %s, address is out of pool's range\n", d.Get("name").(string))
 			}
 
-			ipAddresses = []string{d.Get("request_ip").(string)}
+			ipAddresses = []string{requestedIP}
 		} else {
 			return diag.Errorf("Unable to create IPv6 address: %s, address is out of network's range\n", d.Get("name").(string))
 		}
@@ -177,7 +371,13 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 			poolID = poolInfo["id"].(string)
 		}
 
-		ipAddresses, ipErr = ip6addressfindfree(subnetInfo["id"].(string), poolID, meta)
+		exclude := toStringArray(d.Get("exclude").([]interface{}))
+
+		if d.Get("allocation_strategy").(string) == "first" && len(exclude) == 0 {
+			ipAddresses, ipErr = ip6addressfindfree(subnetInfo["id"].(string), poolID, meta)
+		} else {
+			ipAddresses, ipErr = ipaddressfindbystrategy(subnetInfo, poolInfo, d.Get("allocation_strategy").(string), d.Get("allocation_offset").(int), exclude, true, meta)
+		}
 
 		if ipErr != nil {
 			// Reporting a failure
@@ -201,6 +401,7 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 
 		// Building class_parameters
 		parameters.Add("ip6_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("ip6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance")).Encode())
 
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip6_address6_add", &parameters)
@@ -215,6 +416,12 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 address (oid): %s\n", oid))
 					d.SetId(oid)
 					d.Set("address", ipAddresses[i])
+
+					// Registering the aliases along with the IPv6 address
+					if err := ipaliasesapply(d, true, meta); err != nil {
+						return diag.FromErr(err)
+					}
+
 					return nil
 				}
 			} else {
@@ -269,6 +476,7 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Building class_parameters
 	parameters.Add("ip6_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("ip6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip6_address6_add", &parameters)
@@ -282,6 +490,14 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				// Reconciling the aliases of the IPv6 address
+				if d.HasChanges("aliases", "ignore_aliases") {
+					if err := ipaliasesapply(d, true, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
 				return nil
 			}
 		}
@@ -357,7 +573,7 @@ func resourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta in
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("subnet", buf[0]["subnet6_name"].(string))
+			ipreferenceset(d, "subnet", buf[0]["subnet6_name"].(string))
 			d.Set("address", hexip6toip6(buf[0]["ip6_addr"].(string)))
 			d.Set("name", buf[0]["ip6_name"].(string))
 
@@ -383,6 +599,18 @@ func resourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta in
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["ip6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+			}
+
+			// Updating local aliases
+			if err := ipaliasesread(d, true, meta); err != nil {
+				return diag.FromErr(err)
+			}
 
 			return nil
 		}
@@ -430,6 +658,10 @@ func resourceip6addressImportState(ctx context.Context, d *schema.ResourceData,
 			d.Set("mac", buf[0]["mac_addr"].(string))
 			d.Set("class", buf[0]["ip6_class_name"].(string))
 
+			// Allocation settings are not stored on SOLIDserver, relying on defaults
+			d.Set("allocation_strategy", "first")
+			d.Set("allocation_offset", 0)
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip6_class_parameters"].(string))
@@ -444,6 +676,13 @@ func resourceip6addressImportState(ctx context.Context, d *schema.ResourceData,
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["ip6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
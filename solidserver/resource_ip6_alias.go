@@ -22,6 +22,7 @@ func resourceip6alias() *schema.Resource {
 		Description: heredoc.Doc(`
 			IP aliases allows to register multiple names for a single IP address.
 			They are pretty useful to keep IPAM in sync with the DNS handling CNAME(s) from a single repository.
+			They conflict with the aliases attribute of the solidserver_ip6_address they target, which must set ignore_aliases.
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,447 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func resourceip6delegationpool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceip6delegationpoolCreate,
+		ReadContext:   resourceip6delegationpoolRead,
+		UpdateContext: resourceip6delegationpoolUpdate,
+		DeleteContext: resourceip6delegationpoolDelete,
+		CustomizeDiff: resourceip6delegationpoolCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceip6delegationpoolImportState,
+		},
+
+		Description: heredoc.Doc(`
+			IPv6 delegation pools are non terminal IPv6 subnets dedicated to prefix delegation (DHCPv6-PD).
+			They hand out sub-prefixes of a single size, optionally aligned on nibble boundaries, to the
+			IPv6 subnets referencing them through their delegation_pool attribute.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the IPv6 delegation pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the block into which creating the IPv6 delegation pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv6 delegation pool network address.",
+				ValidateFunc: validation.IsIPAddress,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The expected IPv6 delegation pool's prefix length (ex: 48 for a '/48').",
+				ValidateFunc: validation.IntBetween(0, 128),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"delegated_prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the IPv6 subnets delegated from the pool (ex: 56 for '/56' prefixes).",
+				ValidateFunc: validation.IntBetween(1, 128),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"align_to_nibble": {
+				Type:        schema.TypeBool,
+				Description: "Only delegate prefixes standing on a nibble boundary, so each one maps onto its own reverse zone or is the first of the enclosing nibble prefix for lengths that are not a multiple of 4 (Default: false).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 delegation pool prefix.",
+				Computed:    true,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 delegation pool network address.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 delegation pool to create.",
+				Required:    true,
+				ForceNew:    false,
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the IPv6 delegation pool while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv6 delegation pool.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the IPv6 delegation pool.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv6 delegation pool, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Validate at plan time that the delegated prefixes fit within the IPv6 delegation pool
+func resourceip6delegationpoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("prefix_size") || !d.NewValueKnown("delegated_prefix_size") {
+		return nil
+	}
+
+	if d.Get("delegated_prefix_size").(int) <= d.Get("prefix_size").(int) {
+		return fmt.Errorf("Unable to create IPv6 delegation pool: %s, delegated /%d prefixes don't fit within a /%d pool\n", d.Get("name").(string), d.Get("delegated_prefix_size").(int), d.Get("prefix_size").(int))
+	}
+
+	return nil
+}
+
+// Build the class parameters of an IPv6 delegation pool, flagging it as such
+func ip6delegationpoolclassparameters(d *schema.ResourceData) url.Values {
+	classParameters := url.Values{}
+
+	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+		classParameters.Add(k, v.(string))
+	}
+
+	classParameters.Set("delegation_pool", "1")
+	classParameters.Set("delegated_prefix_size", strconv.Itoa(d.Get("delegated_prefix_size").(int)))
+
+	if d.Get("align_to_nibble").(bool) {
+		classParameters.Set("delegated_align_to_nibble", "1")
+	} else {
+		classParameters.Set("delegated_align_to_nibble", "0")
+	}
+
+	return classParameters
+}
+
+// Set the local state of an IPv6 delegation pool from the information retrieved from SOLIDserver
+func ip6delegationpoolset(d *schema.ResourceData, entry map[string]interface{}) {
+	d.Set("space", entry["site_name"].(string))
+	ipreferenceset(d, "block", entry["parent_subnet6_name"].(string))
+	d.Set("name", entry["subnet6_name"].(string))
+	d.Set("class", entry["subnet6_class_name"].(string))
+
+	if startAddr, startAddrExist := entry["start_ip6_addr"].(string); startAddrExist {
+		d.Set("address", hexip6toip6(startAddr))
+
+		if prefixSize, prefixSizeErr := strconv.Atoi(entry["subnet6_prefix"].(string)); prefixSizeErr == nil {
+			d.Set("prefix_size", prefixSize)
+			d.Set("prefix", hexip6toip6(startAddr)+"/"+strconv.Itoa(prefixSize))
+		}
+	}
+
+	// Updating local class_parameters
+	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+	retrievedClassParameters, _ := url.ParseQuery(entry["subnet6_class_parameters"].(string))
+	computedClassParameters := map[string]string{}
+
+	if delegatedPrefixSize, delegatedPrefixSizeErr := strconv.Atoi(retrievedClassParameters.Get("delegated_prefix_size")); delegatedPrefixSizeErr == nil {
+		d.Set("delegated_prefix_size", delegatedPrefixSize)
+	}
+
+	d.Set("align_to_nibble", retrievedClassParameters.Get("delegated_align_to_nibble") == "1")
+
+	for ck := range currentClassParameters {
+		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+			computedClassParameters[ck] = rv[0]
+		} else {
+			computedClassParameters[ck] = ""
+		}
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+	d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+}
+
+func resourceip6delegationpoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blockInfo, blockErr := ip6subnetinfobyname(siteID, d.Get("block").(string), false, meta)
+	if blockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blockErr)
+	}
+
+	// The delegation pool itself must stand on a nibble boundary for its delegated prefixes to do so
+	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), d.Get("align_to_nibble").(bool), meta)
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	for i := 0; i < len(subnetAddresses); i++ {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("subnet6_name", d.Get("name").(string))
+		parameters.Add("subnet6_addr", hexip6toip6(subnetAddresses[i]))
+		parameters.Add("subnet6_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+		parameters.Add("subnet6_class_name", d.Get("class").(string))
+		parameters.Add("use_reversed_relative_position", "1")
+		parameters.Add("relative_position", "0")
+		parameters.Add("is_terminal", "0")
+		parameters.Add("subnet6_class_parameters", ip6delegationpoolclassparameters(d).Encode())
+
+		// Sending the creation request
+		resp, body, err := s.Request("post", "rest/ip6_subnet6_add", &parameters)
+
+		if err == nil {
+			var buf [](map[string]interface{})
+			json.Unmarshal([]byte(body), &buf)
+
+			prefix := hexip6toip6(subnetAddresses[i]) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+
+			// Checking the answer
+			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 delegation pool (oid): %s\n", oid))
+					d.SetId(oid)
+					d.Set("prefix", prefix)
+					d.Set("address", hexip6toip6(subnetAddresses[i]))
+					return nil
+				}
+			} else {
+				if len(buf) > 0 {
+					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+						tflog.Debug(ctx, fmt.Sprintf("Failed IPv6 delegation pool registration: %s with prefix: %s (%s)\n", d.Get("name").(string), prefix, errMsg))
+					} else {
+						tflog.Debug(ctx, fmt.Sprintf("Failed IPv6 delegation pool registration: %s with prefix: %s\n", d.Get("name").(string), prefix))
+					}
+				} else {
+					tflog.Debug(ctx, fmt.Sprintf("Failed IPv6 delegation pool registration: %s with prefix: %s\n", d.Get("name").(string), prefix))
+				}
+			}
+		} else {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+	}
+
+	// Reporting a failure
+	return diag.Errorf("Unable to create IPv6 delegation pool: %s, unable to find a suitable prefix\n", d.Get("name").(string))
+}
+
+func resourceip6delegationpoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", d.Id())
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("subnet6_name", d.Get("name").(string))
+	parameters.Add("subnet6_class_name", d.Get("class").(string))
+	parameters.Add("subnet6_class_parameters", ip6delegationpoolclassparameters(d).Encode())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip6_subnet6_add", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 delegation pool (oid): %s\n", oid))
+				d.SetId(oid)
+				return nil
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to update IPv6 delegation pool: %s (%s)", d.Get("name").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to update IPv6 delegation pool: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceip6delegationpoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Refusing to delete a protected IPv6 delegation pool or one still holding delegated prefixes
+	if err := deletionprotectioncheck(d, "IPv6 delegation pool", map[string]string{
+		"ip6_block6_subnet6_count": "parent_subnet6_id='" + d.Id() + "'",
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", d.Id())
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip6_subnet6_delete", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to delete IPv6 delegation pool: %s (%s)", d.Get("name").(string), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to delete IPv6 delegation pool: %s", d.Get("name").(string))
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted IPv6 delegation pool (oid): %s\n", d.Id()))
+
+		// Unset local ID
+		d.SetId("")
+
+		// Reporting a success
+		return nil
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceip6delegationpoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			ip6delegationpoolset(d, buf[0])
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 delegation pool: %s (%s)\n", d.Get("name"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 delegation pool (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IPv6 delegation pool: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceip6delegationpoolImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			ip6delegationpoolset(d, buf[0])
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import IPv6 delegation pool (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import IPv6 delegation pool (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import IPv6 delegation pool (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
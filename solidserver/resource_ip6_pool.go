@@ -20,6 +20,7 @@ func resourceip6pool() *schema.Resource {
 		ReadContext:   resourceip6poolRead,
 		UpdateContext: resourceip6poolUpdate,
 		DeleteContext: resourceip6poolDelete,
+		CustomizeDiff: resourceip6poolCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceip6poolImportState,
 		},
@@ -48,14 +49,14 @@ func resourceip6pool() *schema.Resource {
 				Description:  "The IPv6 pool's lower IPv6 address.",
 				ValidateFunc: validation.IsIPAddress,
 				Required:     true,
-				ForceNew:     true,
+				ForceNew:     false,
 			},
 			"end": {
 				Type:         schema.TypeString,
 				Description:  "The IPv6 pool's higher IPv6 address.",
 				ValidateFunc: validation.IsIPAddress,
 				Required:     true,
-				ForceNew:     true,
+				ForceNew:     false,
 			},
 			"dhcp_range": {
 				Type:        schema.TypeBool,
@@ -64,6 +65,20 @@ func resourceip6pool() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IPv6 pool is read-only, preventing any address allocation within it (Default: false). A DHCP range pool is always read-only.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"reserved": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IPv6 pool is reserved, keeping its addresses out of the automatic address allocation (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Description: "The name of the IPv6 pool to create.",
@@ -96,10 +111,87 @@ func resourceip6pool() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv6 pool, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IPv6 pool, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_propagation": {
+				Type:         schema.TypeMap,
+				Description:  "The propagation property of the class parameters associated to the IPv6 pool, as a map of class parameter name to 'propagate' (value pushed down to the child objects) or 'restrict' (value kept local).",
+				ValidateFunc: resourceclassparamspropagationvalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested range fits within the parent subnet
+func resourceip6poolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only check new or moving IPv6 pools, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "subnet", "start", "end") {
+		return nil
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("subnet") || !d.NewValueKnown("start") || !d.NewValueKnown("end") {
+		return nil
+	}
+
+	poolStartHexIP := ip6tohexip6(shortip6tolongip6(d.Get("start").(string)))
+	poolEndHexIP := ip6tohexip6(shortip6tolongip6(d.Get("end").(string)))
+
+	if strings.Compare(poolStartHexIP, poolEndHexIP) == 1 {
+		return fmt.Errorf("Unable to create IPv6 pool: %s, start address %s is higher than end address %s\n", d.Get("name").(string), d.Get("start").(string), d.Get("end").(string))
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 pool: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	subnetInfo, subnetErr := ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil || subnetInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 pool: %s, unable to find subnet: %s\n", d.Get("name").(string), d.Get("subnet").(string)))
+		return nil
+	}
+
+	if strings.Compare(poolStartHexIP, subnetInfo["start_hex_addr"].(string)) == -1 || strings.Compare(poolEndHexIP, subnetInfo["end_hex_addr"].(string)) == 1 {
+		return fmt.Errorf("Unable to create IPv6 pool: %s, range %s - %s is out of network's range (%s - %s)\n", d.Get("name").(string), d.Get("start").(string), d.Get("end").(string), hexip6toip6(subnetInfo["start_hex_addr"].(string)), hexip6toip6(subnetInfo["end_hex_addr"].(string)))
+	}
+
+	return nil
+}
+
 func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -129,20 +221,32 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 	// Building class_parameters
 	classParameters := url.Values{}
 
-	// Generate class parameter for dhcp range sync
+	// Generate class parameter for dhcp range sync, a DHCP range pool is always read-only
 	if d.Get("dhcp_range").(bool) {
 		parameters.Add("pool6_read_only", "1")
 		classParameters.Add("dhcprange6", "1")
 	} else {
-		parameters.Add("pool6_read_only", "0")
+		if d.Get("read_only").(bool) {
+			parameters.Add("pool6_read_only", "1")
+		} else {
+			parameters.Add("pool6_read_only", "0")
+		}
+
 		classParameters.Add("dhcprange6", "0")
 	}
 
+	if d.Get("reserved").(bool) {
+		parameters.Add("pool6_reserved", "1")
+	} else {
+		parameters.Add("pool6_reserved", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
 
 	parameters.Add("pool6_class_parameters", classParameters.Encode())
+	parameters.Add("pool6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the creation request
 	resp, body, err := s.Request("post", "rest/ip6_pool6_add", &parameters)
@@ -188,23 +292,49 @@ func resourceip6poolUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("pool6_name", d.Get("name").(string))
 	parameters.Add("pool6_class_name", d.Get("class").(string))
 
+	// Moving or resizing the IPv6 pool in place, its allocated addresses must remain within the new range
+	if d.HasChanges("start", "end") {
+		poolStartHexIP := ip6tohexip6(shortip6tolongip6(d.Get("start").(string)))
+		poolEndHexIP := ip6tohexip6(shortip6tolongip6(d.Get("end").(string)))
+
+		if err := ippoolmovecheck(d.Id(), poolStartHexIP, poolEndHexIP, true, meta); err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		parameters.Add("start_addr", poolStartHexIP)
+		parameters.Add("end_addr", poolEndHexIP)
+	}
+
 	// Building class_parameters
 	classParameters := url.Values{}
 
-	// Generate class parameter for dhcp range sync
+	// Generate class parameter for dhcp range sync, a DHCP range pool is always read-only
 	if d.Get("dhcp_range").(bool) {
 		parameters.Add("pool6_read_only", "1")
 		classParameters.Add("dhcprange6", "1")
 	} else {
-		parameters.Add("pool6_read_only", "0")
+		if d.Get("read_only").(bool) {
+			parameters.Add("pool6_read_only", "1")
+		} else {
+			parameters.Add("pool6_read_only", "0")
+		}
+
 		classParameters.Add("dhcprange6", "0")
 	}
 
+	if d.Get("reserved").(bool) {
+		parameters.Add("pool6_reserved", "1")
+	} else {
+		parameters.Add("pool6_reserved", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
 
 	parameters.Add("pool6_class_parameters", classParameters.Encode())
+	parameters.Add("pool6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip6_pool6_add", &parameters)
@@ -295,6 +425,22 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 			d.Set("name", buf[0]["pool6_name"].(string))
 			d.Set("class", buf[0]["pool6_class_name"].(string))
 
+			// Only update the range if it actually moved, IPv6 addresses may be written in several ways
+			if start, startExist := buf[0]["pool6_start_ip6_addr"].(string); startExist && start != ip6tohexip6(shortip6tolongip6(d.Get("start").(string))) {
+				d.Set("start", hexip6toip6(start))
+			}
+
+			if end, endExist := buf[0]["pool6_end_ip6_addr"].(string); endExist && end != ip6tohexip6(shortip6tolongip6(d.Get("end").(string))) {
+				d.Set("end", hexip6toip6(end))
+			}
+
+			// A DHCP range pool is always read-only, only track the flag when set on its own
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool6_read_only"] == "1")
+			}
+
+			d.Set("reserved", buf[0]["pool6_reserved"] == "1")
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool6_class_parameters"].(string))
@@ -317,6 +463,14 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["pool6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return nil
 		}
@@ -360,6 +514,22 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 			d.Set("name", buf[0]["pool6_name"].(string))
 			d.Set("class", buf[0]["pool6_class_name"].(string))
 
+			// Only update the range if it actually moved, IPv6 addresses may be written in several ways
+			if start, startExist := buf[0]["pool6_start_ip6_addr"].(string); startExist && start != ip6tohexip6(shortip6tolongip6(d.Get("start").(string))) {
+				d.Set("start", hexip6toip6(start))
+			}
+
+			if end, endExist := buf[0]["pool6_end_ip6_addr"].(string); endExist && end != ip6tohexip6(shortip6tolongip6(d.Get("end").(string))) {
+				d.Set("end", hexip6toip6(end))
+			}
+
+			// A DHCP range pool is always read-only, only track the flag when set on its own
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool6_read_only"] == "1")
+			}
+
+			d.Set("reserved", buf[0]["pool6_reserved"] == "1")
+
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool6_class_parameters"].(string))
@@ -382,6 +552,14 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["pool6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -22,6 +22,7 @@ func resourceip6subnet() *schema.Resource {
 		ReadContext:   resourceip6subnetRead,
 		UpdateContext: resourceip6subnetUpdate,
 		DeleteContext: resourceip6subnetDelete,
+		CustomizeDiff: resourceip6subnetCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceip6subnetImportState,
 		},
@@ -45,6 +46,21 @@ func resourceip6subnet() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"delegation_pool": {
+				Type:          schema.TypeString,
+				Description:   "The name of the IPv6 delegation pool from which delegating the IPv6 subnet (Alternative to block).",
+				Optional:      true,
+				ForceNew:      true,
+				Default:       "",
+				ConflictsWith: []string{"block"},
+			},
+			"align_to_nibble": {
+				Type:        schema.TypeBool,
+				Description: "Only allocate the IPv6 subnet on a nibble boundary (Default: false). Prefixes whose length is a multiple of 4 (ex: /56, /60) always map onto their own reverse zone, other ones are allocated as the first prefix of the enclosing nibble prefix (ex: a /62 at the start of a /60).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
 			"request_ip": {
 				Type:         schema.TypeString,
 				Description:  "The optionally requested subnet IPv6 address.",
@@ -57,7 +73,7 @@ func resourceip6subnet() *schema.Resource {
 				Type:        schema.TypeInt,
 				Description: "The expected IPv6 subnet's prefix length (ex: 24 for a '/24').",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -72,16 +88,105 @@ func resourceip6subnet() *schema.Resource {
 			},
 			"gateway_offset": {
 				Type:        schema.TypeInt,
-				Description: "Offset for creating the gateway. Default is 0 (No gateway).",
+				Description: "Offset for creating the gateway, a negative offset is counted backward from the end of the subnet. Default is 0 (No gateway).",
 				Optional:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 				Default:     0,
 			},
 			"gateway": {
 				Type:        schema.TypeString,
 				Description: "The subnet's computed gateway.",
 				Computed:    true,
-				ForceNew:    true,
+			},
+			"gateway_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the gateway's IPv6 address (Default: SOLIDserver's naming), the FQDN of its DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the gateway's IPv6 address.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the gateway's IPv6 address.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"gateway_dns_server": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name, hosting the gateway's DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_dns_view": {
+				Type:        schema.TypeString,
+				Description: "The View name of the gateway's DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_dns_forward": {
+				Type:         schema.TypeBool,
+				Description:  "Create the forward DNS record (AAAA) of the gateway, named after gateway_name (Default: false).",
+				Optional:     true,
+				ForceNew:     false,
+				Default:      false,
+				RequiredWith: []string{"gateway_name", "gateway_dns_server"},
+			},
+			"gateway_dns_reverse": {
+				Type:         schema.TypeBool,
+				Description:  "Create the reverse DNS record (PTR) of the gateway, pointing to gateway_name (Default: false).",
+				Optional:     true,
+				ForceNew:     false,
+				Default:      false,
+				RequiredWith: []string{"gateway_name", "gateway_dns_server"},
+			},
+			"reserved_addresses": {
+				Type:        schema.TypeList,
+				Description: "The addresses reserved at fixed offsets within the IPv6 subnet (HSRP/VRRP, infrastructure, ...), created along with it.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"offset": {
+							Type:        schema.TypeInt,
+							Description: "Offset of the reserved address within the subnet, a negative offset is counted backward from the end of the subnet.",
+							Required:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the reserved address.",
+							Required:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the reserved address.",
+							Optional:    true,
+							Default:     "",
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC Address of the reserved address.",
+							Optional:    true,
+							Default:     "",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The computed reserved address.",
+							Computed:    true,
+						},
+					},
+				},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -96,6 +201,38 @@ func resourceip6subnet() *schema.Resource {
 				ForceNew:    true,
 				Default:     true,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the IPv6 subnet while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IPv6 subnet, requires either vlan or vlan_id.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"vlan_id": {
+				Type:          schema.TypeInt,
+				Description:   "The VLAN ID of the VLAN associated to the IPv6 subnet.",
+				Optional:      true,
+				ForceNew:      false,
+				Default:       0,
+				RequiredWith:  []string{"vlan_domain"},
+				ConflictsWith: []string{"vlan"},
+			},
+			"vlan": {
+				Type:          schema.TypeString,
+				Description:   "The name of the VLAN associated to the IPv6 subnet (Alternative to vlan_id).",
+				Optional:      true,
+				ForceNew:      false,
+				Default:       "",
+				RequiredWith:  []string{"vlan_domain"},
+				ConflictsWith: []string{"vlan_id"},
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IPv6 subnet.",
@@ -112,10 +249,194 @@ func resourceip6subnet() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IPv6 subnet, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IPv6 subnet, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_propagation": {
+				Type:         schema.TypeMap,
+				Description:  "The propagation property of the class parameters associated to the IPv6 subnet, as a map of class parameter name to 'propagate' (value pushed down to the child objects) or 'restrict' (value kept local).",
+				ValidateFunc: resourceclassparamspropagationvalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested prefix, gateway and reserved addresses fit within the parent block
+func resourceip6subnetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := subnetvlancheck(d); err != nil {
+		return err
+	}
+
+	// Only check new or moving IPv6 subnets, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "block", "delegation_pool", "align_to_nibble", "request_ip", "prefix_size", "gateway_offset", "reserved_addresses", "terminal") {
+		return nil
+	}
+
+	// Resizing an existing IPv6 subnet in place or moving its gateway changes its computed attributes
+	if d.Id() != "" && d.HasChanges("prefix_size", "gateway_offset") {
+		computed := []string{}
+
+		if d.HasChange("prefix_size") {
+			computed = append(computed, "prefix")
+		}
+
+		if d.HasChange("gateway_offset") || d.Get("gateway_offset").(int) != 0 {
+			computed = append(computed, "gateway")
+		}
+
+		for _, key := range computed {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.NewValueKnown("prefix_size") || !d.NewValueKnown("gateway_offset") || !d.NewValueKnown("request_ip") {
+		return nil
+	}
+
+	prefixSize := d.Get("prefix_size").(int)
+
+	if prefixSize < 0 || prefixSize > 128 {
+		return fmt.Errorf("Unable to create IPv6 subnet: %s, invalid prefix size: /%d\n", d.Get("name").(string), prefixSize)
+	}
+
+	subnetSize := new(big.Int).Lsh(big.NewInt(1), uint(128-prefixSize))
+
+	// Ensure the gateway stands within the subnet
+	if goffset := d.Get("gateway_offset").(int); goffset != 0 && big.NewInt(int64(abs(goffset))).Cmp(subnetSize) >= 0 {
+		return fmt.Errorf("Unable to create IPv6 subnet: %s, gateway_offset %d is beyond the size of a /%d subnet\n", d.Get("name").(string), goffset, prefixSize)
+	}
+
+	// Ensure the reserved addresses stand within the subnet, apart from the gateway
+	if d.NewValueKnown("reserved_addresses") {
+		reserved := d.Get("reserved_addresses").([]interface{})
+
+		if err := subnetreservedcheck(reserved, d.Get("gateway_offset").(int)); err != nil {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, %s\n", d.Get("name").(string), err)
+		}
+
+		for _, r := range reserved {
+			if offset := r.(map[string]interface{})["offset"].(int); big.NewInt(int64(abs(offset))).Cmp(subnetSize) >= 0 {
+				return fmt.Errorf("Unable to create IPv6 subnet: %s, reserved address offset %d is beyond the size of a /%d subnet\n", d.Get("name").(string), offset, prefixSize)
+			}
+		}
+	}
+
+	// Ensure the requested IP address is the network address of the expected prefix
+	if requestedIP := d.Get("request_ip").(string); len(requestedIP) > 0 {
+		requestedStart, _ := new(big.Int).SetString(ip6tohexip6(shortip6tolongip6(requestedIP)), 16)
+
+		if requestedStart == nil || new(big.Int).Mod(requestedStart, subnetSize).Sign() != 0 {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, %s is not a valid network address for a /%d prefix\n", d.Get("name").(string), requestedIP, prefixSize)
+		}
+
+		if d.Get("align_to_nibble").(bool) && !ip6nibblealigned(ip6tohexip6(shortip6tolongip6(requestedIP)), prefixSize) {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, %s/%d is not aligned on a nibble boundary\n", d.Get("name").(string), requestedIP, prefixSize)
+		}
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("block") || !d.NewValueKnown("delegation_pool") || !d.NewValueKnown("terminal") {
+		return nil
+	}
+
+	if len(d.Get("block").(string)) == 0 && len(d.Get("delegation_pool").(string)) == 0 {
+		if d.Get("terminal").(bool) {
+			return fmt.Errorf("Can't create a terminal IPv6 block subnet: %s\n", d.Get("name").(string))
+		}
+
+		return nil
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 subnet: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	// Delegated IPv6 subnets are carved out of their delegation pool, all of the same size
+	if poolName := d.Get("delegation_pool").(string); len(poolName) > 0 {
+		poolInfo, poolErr := ip6delegationpoolinfobyname(siteID, poolName, meta)
+
+		if poolErr != nil || poolInfo == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 subnet: %s, unable to find delegation pool: %s\n", d.Get("name").(string), poolName))
+			return nil
+		}
+
+		if delegatedPrefixSize := poolInfo["delegated_prefix_size"].(int); delegatedPrefixSize != 0 && prefixSize != delegatedPrefixSize {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, delegation pool %s only hands out /%d prefixes\n", d.Get("name").(string), poolName, delegatedPrefixSize)
+		}
+
+		if requestedIP := d.Get("request_ip").(string); len(requestedIP) > 0 && poolInfo["align_to_nibble"].(bool) && !ip6nibblealigned(ip6tohexip6(shortip6tolongip6(requestedIP)), prefixSize) {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, %s/%d is not aligned on a nibble boundary\n", d.Get("name").(string), requestedIP, prefixSize)
+		}
+
+		return ip6subnetfitcheck(d.Get("name").(string), d.Get("request_ip").(string), prefixSize, "delegation pool", poolName, poolInfo)
+	}
+
+	blockInfo, blockErr := ip6subnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil || blockInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IPv6 subnet: %s, unable to find block: %s\n", d.Get("name").(string), d.Get("block").(string)))
+		return nil
+	}
+
+	return ip6subnetfitcheck(d.Get("name").(string), d.Get("request_ip").(string), prefixSize, "block", d.Get("block").(string), blockInfo)
+}
+
+// Ensure an IPv6 subnet, and its requested prefix if any, fit within its parent block or delegation pool
+func ip6subnetfitcheck(name string, requestedIP string, prefixSize int, parentType string, parentName string, blockInfo map[string]interface{}) error {
+	subnetSize := new(big.Int).Lsh(big.NewInt(1), uint(128-prefixSize))
+
+	if blockPrefixLength, blockPrefixLengthExist := blockInfo["prefix_length"].(int); blockPrefixLengthExist && prefixSize < blockPrefixLength {
+		return fmt.Errorf("Unable to create IPv6 subnet: %s, a /%d prefix doesn't fit within %s %s (%s/%d)\n", name, prefixSize, parentType, parentName, hexip6toip6(blockInfo["start_hex_addr"].(string)), blockPrefixLength)
+	}
+
+	if len(requestedIP) > 0 {
+		requestedStart, _ := new(big.Int).SetString(ip6tohexip6(shortip6tolongip6(requestedIP)), 16)
+		requestedEnd := new(big.Int).Add(requestedStart, new(big.Int).Sub(subnetSize, big.NewInt(1)))
+		blockStart, _ := new(big.Int).SetString(blockInfo["start_hex_addr"].(string), 16)
+		blockEnd, _ := new(big.Int).SetString(blockInfo["end_hex_addr"].(string), 16)
+
+		if blockStart != nil && blockEnd != nil && (requestedStart.Cmp(blockStart) < 0 || requestedEnd.Cmp(blockEnd) > 0) {
+			return fmt.Errorf("Unable to create IPv6 subnet: %s, prefix %s/%d is out of %s's range (%s - %s)\n", name, requestedIP, prefixSize, parentType, hexip6toip6(blockInfo["start_hex_addr"].(string)), hexip6toip6(blockInfo["end_hex_addr"].(string)))
+		}
+	}
+
+	return nil
+}
+
 func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	blockInfo := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
@@ -128,8 +449,26 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(siteErr)
 	}
 
-	// If a block is specified, look for free IP subnet within this block
-	if len(d.Get("block").(string)) > 0 {
+	alignToNibble := d.Get("align_to_nibble").(bool)
+
+	// If a delegation pool is specified, look for free IP subnet of the delegated size within this pool
+	// Else if a block is specified, look for free IP subnet within this block
+	if len(d.Get("delegation_pool").(string)) > 0 {
+		var poolErr error = nil
+
+		blockInfo, poolErr = ip6delegationpoolinfobyname(siteID, d.Get("delegation_pool").(string), meta)
+
+		if poolErr != nil {
+			// Reporting a failure
+			return diag.FromErr(poolErr)
+		}
+
+		if delegatedPrefixSize := blockInfo["delegated_prefix_size"].(int); delegatedPrefixSize != 0 && d.Get("prefix_size").(int) != delegatedPrefixSize {
+			return diag.Errorf("Unable to create IPv6 subnet: %s, delegation pool %s only hands out /%d prefixes\n", d.Get("name").(string), d.Get("delegation_pool").(string), delegatedPrefixSize)
+		}
+
+		alignToNibble = alignToNibble || blockInfo["align_to_nibble"].(bool)
+	} else if len(d.Get("block").(string)) > 0 {
 		var blockErr error = nil
 
 		blockInfo, blockErr = ip6subnetinfobyname(siteID, d.Get("block").(string), false, meta)
@@ -148,7 +487,7 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
+	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), alignToNibble, meta)
 
 	if subnetErr != nil {
 		// Reporting a failure
@@ -165,8 +504,13 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		parameters.Add("subnet6_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
 		parameters.Add("subnet6_class_name", d.Get("class").(string))
 
-		// If no block specified, create an IP block
-		if len(d.Get("block").(string)) == 0 {
+		// Associate the subnet to a VLAN if required
+		if d.Get("vlan_domain").(string) != "" {
+			urlfromsubnetvlan(&parameters, d)
+		}
+
+		// If no block nor delegation pool specified, create an IP block
+		if len(d.Get("block").(string)) == 0 && len(d.Get("delegation_pool").(string)) == 0 {
 			parameters.Add("subnet_level", "0")
 		} else {
 			parameters.Add("use_reversed_relative_position", "1")
@@ -206,6 +550,7 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 			classParameters.Add(k, v.(string))
 		}
 		parameters.Add("subnet6_class_parameters", classParameters.Encode())
+		parameters.Add("subnet6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 		// Random Delay
 		time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
@@ -229,6 +574,25 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 					if goffset != 0 {
 						d.Set("gateway", gateway)
 					}
+
+					// Registering the reserved addresses along with the subnet
+					if err := subnetreservedapply(d, true, meta); err != nil {
+						return diag.FromErr(err)
+					}
+
+					// Registering the gateway's name, class and DNS records along with the subnet
+					if goffset != 0 {
+						if subnetgatewaymanaged(d) {
+							if err := subnetgatewayapply(d, true, meta); err != nil {
+								return diag.FromErr(err)
+							}
+						}
+
+						if err := subnetgatewaydnsapply(d, true, meta); err != nil {
+							return diag.FromErr(err)
+						}
+					}
+
 					return nil
 				}
 			} else {
@@ -262,21 +626,107 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("subnet6_name", d.Get("name").(string))
 	parameters.Add("subnet6_class_name", d.Get("class").(string))
 
+	// Update the VLAN association if required
+	if d.HasChanges("vlan_domain", "vlan_id", "vlan") {
+		urlfromsubnetvlan(&parameters, d)
+	}
+
 	if d.Get("terminal").(bool) {
 		parameters.Add("is_terminal", "1")
 	} else {
 		parameters.Add("is_terminal", "0")
 	}
 
-	// Building class_parameters
-	classParameters := url.Values{}
+	// The reserved addresses no longer expected are released once the subnet is known to fit, they may stand beyond its new end
+	releasing := []map[string]interface{}{}
 
-	// Generate class parameter for the gateway if required
+	if d.HasChanges("reserved_addresses", "prefix_size") {
+		releasing = subnetreservedreleasing(d, false, true)
+	}
+
+	// The gateway is computed again as it may move along with its offset or the end of the subnet
+	oldGateway, _ := d.GetChange("gateway")
 	goffset := d.Get("gateway_offset").(int)
 
+	if d.HasChanges("gateway_offset", "prefix_size") {
+		if goffset != 0 {
+			d.Set("gateway", subnetaddressatoffset(d.Get("address").(string), d.Get("prefix_size").(int), goffset, true))
+		} else {
+			d.Set("gateway", "")
+		}
+	}
+
+	gatewayMoved := oldGateway.(string) != d.Get("gateway").(string)
+
+	// Resizing the IPv6 subnet in place, its network address remains unchanged
+	// The previous gateway and the reserved addresses released along the way don't prevent the subnet from shrinking
+	if d.HasChange("prefix_size") {
+		ignored := []string{}
+
+		if gatewayMoved && oldGateway.(string) != "" {
+			ignored = append(ignored, oldGateway.(string))
+		}
+
+		for _, r := range releasing {
+			ignored = append(ignored, r["address"].(string))
+		}
+
+		if err := ip6subnetresizecheck(d.Id(), d.Get("address").(string), d.Get("prefix_size").(int), ignored, meta); err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		parameters.Add("subnet6_addr", d.Get("address").(string))
+		parameters.Add("subnet6_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+	}
+
+	released, err := subnetreservedrelease(d, releasing, true, meta)
+	var releasedGateway map[string]interface{}
+
+	// Registering again the released reserved addresses and gateway when the subnet can't be updated
+	rollback := func(err error) diag.Diagnostics {
+		if restoreErr := subnetreservedrestore(d, released, true, meta); restoreErr != nil {
+			return diag.Errorf("%s, %s", err, restoreErr)
+		}
+
+		if restoreErr := subnetgatewayrestore(d.Get("space").(string), oldGateway.(string), releasedGateway, true, meta); restoreErr != nil {
+			return diag.Errorf("%s, %s", err, restoreErr)
+		}
+
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if err != nil {
+		return rollback(err)
+	}
+
+	gatewayDNSChanged := gatewayMoved || d.HasChanges("gateway_name", "gateway_dns_server", "gateway_dns_view", "gateway_dns_forward", "gateway_dns_reverse")
+
+	// Releasing the IP object of the previous gateway, it may stand beyond the new end of the subnet
+	if gatewayMoved && oldGateway.(string) != "" {
+		entry, err := subnetgatewayentry(d.Get("space").(string), oldGateway.(string), true, meta)
+
+		if err != nil {
+			return rollback(err)
+		}
+
+		if err := subnetgatewayrelease(d.Get("space").(string), oldGateway.(string), true, meta); err != nil {
+			return rollback(err)
+		}
+
+		releasedGateway = entry
+	}
+
+	// Building class_parameters
+	classParameters := url.Values{}
+
+	// Generate class parameter for the gateway if required, or drop it along with the gateway
 	if goffset != 0 {
 		classParameters.Add("gateway", d.Get("gateway").(string))
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
+	} else if gatewayMoved {
+		classParameters.Add("gateway", "")
 	}
 
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
@@ -284,6 +734,7 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	parameters.Add("subnet6_class_parameters", classParameters.Encode())
+	parameters.Add("subnet6_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip6_subnet6_add", &parameters)
@@ -297,6 +748,37 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 subnet (oid): %s\n", oid))
 				d.SetId(oid)
+				d.Set("prefix", d.Get("address").(string)+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+
+				// Registering the expected reserved addresses
+				if d.HasChanges("reserved_addresses", "prefix_size") {
+					if err := subnetreservedapply(d, true, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
+				// Releasing the DNS records of the previous gateway
+				if gatewayDNSChanged {
+					if err := subnetgatewaydnsrelease(d, true, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
+				// Registering the gateway's name, class and DNS records
+				if goffset != 0 {
+					if (gatewayMoved && subnetgatewaymanaged(d)) || d.HasChanges("gateway_name", "gateway_class", "gateway_class_parameters") {
+						if err := subnetgatewayapply(d, true, meta); err != nil {
+							return diag.FromErr(err)
+						}
+					}
+
+					if gatewayDNSChanged {
+						if err := subnetgatewaydnsapply(d, true, meta); err != nil {
+							return diag.FromErr(err)
+						}
+					}
+				}
+
 				return nil
 			}
 		}
@@ -304,21 +786,21 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		// Reporting a failure
 		if len(buf) > 0 {
 			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				return diag.Errorf("Unable to update IPv6 subnet: %s (%s)", d.Get("name").(string), errMsg)
+				return rollback(fmt.Errorf("Unable to update IPv6 subnet: %s (%s)", d.Get("name").(string), errMsg))
 			}
 		}
 
-		return diag.Errorf("Unable to update IPv6 subnet: %s\n", d.Get("name").(string))
+		return rollback(fmt.Errorf("Unable to update IPv6 subnet: %s\n", d.Get("name").(string)))
 	}
 
 	// Reporting a failure
-	return diag.FromErr(err)
+	return rollback(err)
 }
 
 func resourceip6subnetgatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	if d.Get("gateway") != nil {
+	if d.Get("gateway").(string) != "" {
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_name", d.Get("space").(string))
@@ -336,11 +818,11 @@ func resourceip6subnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 				// Reporting a failure
 				if len(buf) > 0 {
 					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Unable to delete IPv6 subnet's gateway: %s (%s)", d.Get("gateway").(string), errMsg))
+						return diag.Errorf("Unable to delete IPv6 subnet's gateway: %s (%s)", d.Get("gateway").(string), errMsg)
 					}
 				}
 
-				tflog.Debug(ctx, fmt.Sprintf("Unable to delete IPv6 subnet's gateway: %s", d.Get("gateway").(string)))
+				return diag.Errorf("Unable to delete IPv6 subnet's gateway: %s", d.Get("gateway").(string))
 			}
 
 			// Log deletion
@@ -361,9 +843,40 @@ func resourceip6subnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 func resourceip6subnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	// Delete related resources such as the Gateway
+	// Refusing to delete a protected or non empty IPv6 subnet, ignoring its own gateway and reserved addresses
+	addressWhereClause := "subnet6_id='" + d.Id() + "' AND type='ip6'"
+
+	if d.Get("gateway_offset").(int) != 0 && d.Get("gateway").(string) != "" {
+		addressWhereClause += " AND ip6_addr!='" + ip6tohexip6(shortip6tolongip6(d.Get("gateway").(string))) + "'"
+	}
+
+	for _, r := range d.Get("reserved_addresses").([]interface{}) {
+		if address, _ := r.(map[string]interface{})["address"].(string); address != "" {
+			addressWhereClause += " AND ip6_addr!='" + ip6tohexip6(shortip6tolongip6(address)) + "'"
+		}
+	}
+
+	if err := deletionprotectioncheck(d, "IPv6 subnet", map[string]string{
+		"ip6_block6_subnet6_count": "parent_subnet6_id='" + d.Id() + "'",
+		"ip6_pool6_count":          "subnet6_id='" + d.Id() + "'",
+		"ip6_address6_count":       addressWhereClause,
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Delete related resources such as the reserved addresses and the Gateway
+	if _, err := subnetreservedrelease(d, subnetreservedreleasing(d, true, true), true, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.Get("gateway_offset") != 0 {
-		resourceip6subnetgatewayDelete(ctx, d, meta)
+		if err := subnetgatewaydnsrelease(d, true, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if diags := resourceip6subnetgatewayDelete(ctx, d, meta); diags.HasError() {
+			return diags
+		}
 	}
 
 	// Building parameters
@@ -420,9 +933,17 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("block", buf[0]["parent_subnet6_name"].(string))
+
+			// Delegated IPv6 subnets stand within their delegation pool rather than a block
+			if d.Get("delegation_pool").(string) != "" {
+				ipreferenceset(d, "delegation_pool", buf[0]["parent_subnet6_name"].(string))
+			} else {
+				ipreferenceset(d, "block", buf[0]["parent_subnet6_name"].(string))
+			}
+
 			d.Set("name", buf[0]["subnet6_name"].(string))
 			d.Set("class", buf[0]["subnet6_class_name"].(string))
+			subnetvlanset(d, buf[0], false)
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -448,6 +969,24 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["subnet6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
+
+			// Updating local reserved addresses
+			if err := subnetreservedread(d, d.Id(), true, meta); err != nil {
+				return diag.FromErr(err)
+			}
+
+			// Updating local gateway's name, class and class parameters
+			if err := subnetgatewayread(d, true, meta); err != nil {
+				return diag.FromErr(err)
+			}
 
 			return nil
 		}
@@ -492,6 +1031,7 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 			d.Set("block", buf[0]["parent_subnet6_name"].(string))
 			d.Set("name", buf[0]["subnet6_name"].(string))
 			d.Set("class", buf[0]["subnet6_class_name"].(string))
+			subnetvlanset(d, buf[0], false)
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -517,6 +1057,14 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["subnet6_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
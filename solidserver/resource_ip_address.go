@@ -20,6 +20,7 @@ func resourceipaddress() *schema.Resource {
 		ReadContext:   resourceipaddressRead,
 		UpdateContext: resourceipaddressUpdate,
 		DeleteContext: resourceipaddressDelete,
+		CustomizeDiff: resourceipaddressCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceipaddressImportState,
 		},
@@ -57,6 +58,29 @@ func resourceipaddress() *schema.Resource {
 				ForceNew:     true,
 				Default:      "",
 			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the IP address when none is requested: first, last, random or offset (Default: first). Only used when allocating the IP address.",
+				ValidateFunc: validation.StringInSlice([]string{"first", "last", "random", "offset"}, false),
+				Optional:     true,
+				Default:      "first",
+			},
+			"allocation_offset": {
+				Type:         schema.TypeInt,
+				Description:  "The offset from the start of the subnet of the IP address to allocate, only supported by the offset allocation strategy. Only used when allocating the IP address.",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "The list of addresses, offsets from the start of the subnet or ranges of them (a-b) never to be allocated. Only used when allocating the IP address.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: resourceipallocationexcludevalidate,
+				},
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Description: "The provisionned IP address.",
@@ -85,6 +109,36 @@ func resourceipaddress() *schema.Resource {
 				DiffSuppressFunc: resourcediffsuppresscase,
 				Default:          "",
 			},
+			"aliases": {
+				Type:          schema.TypeSet,
+				Description:   "The aliases (CNAME or A) of the IP address, managed as a whole: aliases missing from the set are removed. Conflicts with solidserver_ip_alias resources targeting the same address, use ignore_aliases along with them.",
+				Optional:      true,
+				ForceNew:      false,
+				ConflictsWith: []string{"ignore_aliases"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The FQDN of the alias.",
+							Required:    true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Description:  "The type of the alias (Supported: A, CNAME; Default: CNAME).",
+							ValidateFunc: validation.StringInSlice([]string{"A", "CNAME"}, false),
+							Optional:     true,
+							Default:      "CNAME",
+						},
+					},
+				},
+			},
+			"ignore_aliases": {
+				Type:        schema.TypeBool,
+				Description: "Leave the aliases of the IP address untouched, when they are managed through solidserver_ip_alias resources (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP address.",
@@ -101,10 +155,96 @@ func resourceipaddress() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IP address, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IP address, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested IP address fits within the subnet and the pool
+func resourceipaddressCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The allocation offset only applies to the offset allocation strategy
+	if d.NewValueKnown("allocation_offset") && d.NewValueKnown("allocation_strategy") && d.Get("allocation_offset").(int) != 0 && d.Get("allocation_strategy").(string) != "offset" {
+		return fmt.Errorf("Unable to create IP address: %s, allocation_offset requires the offset allocation_strategy\n", d.Get("name").(string))
+	}
+
+	// Only check new or moving IP addresses, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "subnet", "pool", "request_ip") {
+		return nil
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("subnet") || !d.NewValueKnown("pool") || !d.NewValueKnown("request_ip") {
+		return nil
+	}
+
+	if len(d.Get("request_ip").(string)) == 0 {
+		return nil
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP address: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil || subnetInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP address: %s, unable to find subnet: %s\n", d.Get("name").(string), d.Get("subnet").(string)))
+		return nil
+	}
+
+	requestedHexIP := iptohexip(d.Get("request_ip").(string))
+
+	if strings.Compare(subnetInfo["start_hex_addr"].(string), requestedHexIP) != -1 ||
+		strings.Compare(requestedHexIP, subnetInfo["end_hex_addr"].(string)) != -1 {
+		return fmt.Errorf("Unable to create IP address: %s, address %s is out of network's range (%s - %s)\n", d.Get("name").(string), d.Get("request_ip").(string), subnetInfo["start_addr"].(string), subnetInfo["end_addr"].(string))
+	}
+
+	if len(d.Get("pool").(string)) > 0 {
+		poolInfo, poolErr := ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+
+		if poolErr != nil || poolInfo == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP address: %s, unable to find pool: %s\n", d.Get("name").(string), d.Get("pool").(string)))
+			return nil
+		}
+
+		if strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
+			strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1 {
+			return fmt.Errorf("Unable to create IP address: %s, address %s is out of pool's range (%s - %s)\n", d.Get("name").(string), d.Get("request_ip").(string), poolInfo["start_addr"].(string), poolInfo["end_addr"].(string))
+		}
+	}
+
+	return nil
+}
+
 func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -183,7 +323,13 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 			poolID = poolInfo["id"].(string)
 		}
 
-		ipAddresses, ipErr = ipaddressfindfree(subnetInfo["id"].(string), poolID, meta)
+		exclude := toStringArray(d.Get("exclude").([]interface{}))
+
+		if d.Get("allocation_strategy").(string) == "first" && len(exclude) == 0 {
+			ipAddresses, ipErr = ipaddressfindfree(subnetInfo["id"].(string), poolID, meta)
+		} else {
+			ipAddresses, ipErr = ipaddressfindbystrategy(subnetInfo, poolInfo, d.Get("allocation_strategy").(string), d.Get("allocation_offset").(int), exclude, false, meta)
+		}
 
 		if ipErr != nil {
 			// Reporting a failure
@@ -207,6 +353,7 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 		// Building class_parameters
 		parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+		parameters.Add("ip_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance")).Encode())
 
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip_add", &parameters)
@@ -221,6 +368,12 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 					tflog.Debug(ctx, fmt.Sprintf("Created IP address (oid): %s\n", oid))
 					d.SetId(oid)
 					d.Set("address", ipAddresses[i])
+
+					// Registering the aliases along with the IP address
+					if err := ipaliasesapply(d, false, meta); err != nil {
+						return diag.FromErr(err)
+					}
+
 					return nil
 				}
 			} else {
@@ -275,6 +428,7 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Building class_parameters
 	parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("ip_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_add", &parameters)
@@ -288,6 +442,14 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				// Reconciling the aliases of the IP address
+				if d.HasChanges("aliases", "ignore_aliases") {
+					if err := ipaliasesapply(d, false, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
 				return nil
 			}
 		}
@@ -363,7 +525,7 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("subnet", buf[0]["subnet_name"].(string))
+			ipreferenceset(d, "subnet", buf[0]["subnet_name"].(string))
 			d.Set("address", hexiptoip(buf[0]["ip_addr"].(string)))
 			d.Set("name", buf[0]["name"].(string))
 
@@ -374,7 +536,7 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			d.Set("class", buf[0]["ip_class_name"].(string))
-			d.Set("pool", buf[0]["pool_name"].(string))
+			ipreferenceset(d, "pool", buf[0]["pool_name"].(string))
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
@@ -390,6 +552,18 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["ip_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+			}
+
+			// Updating local aliases
+			if err := ipaliasesread(d, false, meta); err != nil {
+				return diag.FromErr(err)
+			}
 
 			return nil
 		}
@@ -438,6 +612,10 @@ func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, m
 			d.Set("class", buf[0]["ip_class_name"].(string))
 			d.Set("pool", buf[0]["pool_name"].(string))
 
+			// Allocation settings are not stored on SOLIDserver, relying on defaults
+			d.Set("allocation_strategy", "first")
+			d.Set("allocation_offset", 0)
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["ip_class_parameters"].(string))
@@ -452,6 +630,13 @@ func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, m
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["ip_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -0,0 +1,687 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func resourceipaddressrange() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipaddressrangeCreate,
+		ReadContext:   resourceipaddressrangeRead,
+		UpdateContext: resourceipaddressrangeUpdate,
+		DeleteContext: resourceipaddressrangeDelete,
+		CustomizeDiff: resourceipaddressrangeCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceipaddressrangeImportState,
+		},
+
+		Description: heredoc.Doc(`
+			IP address range resource allows to reserve a block of consecutive IP addresses within a subnet or a pool.
+			The addresses of the range are managed as a single unit and the range can grow or shrink in place.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the IP address range.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet into which creating the IP address range.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of the pool into which creating the IP address range.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested first IP address of the range.",
+				ValidateFunc: validation.IsIPv4Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Description:  "The number of consecutive IP addresses to reserve.",
+				ValidateFunc: validation.IntAtLeast(1),
+				Required:     true,
+				ForceNew:     false,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name template of the IP addresses, '%d' is replaced by the position of the address within the range (starting at 1).",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("%d"), "must contain the '%d' placeholder of the address position"),
+				Required:     true,
+				ForceNew:     false,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IP addresses.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"start_address": {
+				Type:        schema.TypeString,
+				Description: "The first IP address of the range.",
+				Computed:    true,
+			},
+			"end_address": {
+				Type:        schema.TypeString,
+				Description: "The last IP address of the range.",
+				Computed:    true,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The provisionned IP addresses.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Return the name of the IP address at the given position (starting at 1) of the range
+func ipaddressrangename(template string, position int) string {
+	return strings.ReplaceAll(template, "%d", strconv.Itoa(position))
+}
+
+// Return the name template of a range from the names of its first two IP addresses (ex: web-1 and web-2 give web-%d)
+// The position is where the first name holds '1' and the second one '2', the rest of the names being identical
+// Without a second address, the last '1' of the first name is assumed to be the position
+// Or an empty string if no position can be found
+func ipaddressrangetemplate(first string, second string) string {
+	if second != "" {
+		i := 0
+
+		for i < len(first) && i < len(second) && first[i] == second[i] {
+			i++
+		}
+
+		if i < len(first) && i < len(second) && first[i] == '1' && second[i] == '2' && first[i+1:] == second[i+1:] {
+			return first[:i] + "%d" + first[i+1:]
+		}
+
+		return ""
+	}
+
+	if i := strings.LastIndex(first, "1"); i != -1 {
+		return first[:i] + "%d" + first[i+1:]
+	}
+
+	return ""
+}
+
+// Register a single IP address of the range
+// Return the oid of the created IP address
+func ipaddressrangeadd(siteID string, name string, class string, address string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("ip_name", name)
+	parameters.Add("hostaddr", address)
+	parameters.Add("ip_class_name", class)
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", "rest/ip_add", &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Created IP address (oid): %s\n", oid))
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("Unable to create IP address: %s with address: %s (%s)\n", name, address, errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("Unable to create IP address: %s with address: %s\n", name, address)
+}
+
+// Update the name and class of a single IP address of the range
+func ipaddressrangeedit(ipID string, name string, class string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", ipID)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("ip_name", name)
+	parameters.Add("ip_class_name", class)
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip_add", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Updated IP address (oid): %s\n", ipID))
+			return nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("Unable to update IP address: %s (%s)\n", name, errMsg)
+		}
+	}
+
+	return fmt.Errorf("Unable to update IP address: %s\n", name)
+}
+
+// Delete a single IP address of the range
+func ipaddressrangedelete(ipID string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", ipID)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip_delete", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("Unable to delete IP address (oid): %s (%s)\n", ipID, errMsg)
+			}
+		}
+
+		return fmt.Errorf("Unable to delete IP address (oid): %s\n", ipID)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Deleted IP address's oid: %s\n", ipID))
+
+	return nil
+}
+
+// Return the registered IP addresses within the span of the range indexed by their position (starting at 0)
+// The addresses named after the template belong to the range, the other ones are returned apart
+func ipaddressrangemembers(subnetID string, start uint32, size int, template string, meta interface{}) (map[int]map[string]interface{}, map[int]map[string]interface{}, error) {
+	members := map[int]map[string]interface{}{}
+	others := map[int]map[string]interface{}{}
+
+	addresses, err := ipaddressrangelist(subnetID, iptohexip(longtoip(start)), iptohexip(longtoip(start+uint32(size)-1)), meta)
+
+	if err != nil {
+		return members, others, err
+	}
+
+	for _, addr := range addresses {
+		if hexAddr, hexAddrExist := addr["ip_addr"].(string); hexAddrExist {
+			position := int(iptolong(hexiptoip(hexAddr)) - start)
+
+			if name, _ := addr["name"].(string); name == ipaddressrangename(template, position+1) {
+				members[position] = addr
+			} else {
+				others[position] = addr
+			}
+		}
+	}
+
+	return members, others, nil
+}
+
+// Plan the registration of the missing addresses of the range, resizing it or not
+func resourceipaddressrangeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("size") || len(d.Get("addresses").([]interface{})) != d.Get("size").(int) {
+		for _, key := range []string{"end_address", "addresses"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceipaddressrangeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var poolInfo map[string]interface{} = nil
+
+	size := d.Get("size").(int)
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetInfo == nil || subnetErr != nil {
+		// Reporting a failure
+		if subnetInfo == nil {
+			return diag.Errorf("Unable to create IP address range: %s, unable to find requested network\n", d.Get("name").(string))
+		}
+
+		return diag.FromErr(subnetErr)
+	}
+
+	if len(d.Get("pool").(string)) > 0 {
+		var poolErr error = nil
+
+		poolInfo, poolErr = ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+		if poolErr != nil {
+			// Reporting a failure
+			return diag.FromErr(poolErr)
+		}
+
+		if poolInfo == nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to find IP pool: %s\n", d.Get("pool").(string))
+		}
+	}
+
+	// Another client may grab one of the suggested addresses, retry with a new range in that case
+	for try := 0; try < 3; try++ {
+		var startAddr string = ""
+
+		// Determining if a start address was submitted in or if we should get one from the IPAM
+		if len(d.Get("request_ip").(string)) > 0 {
+			startAddr = d.Get("request_ip").(string)
+
+			first := int64(iptolong(startAddr))
+			last := first + int64(size) - 1
+
+			if first <= int64(iptolong(subnetInfo["start_addr"].(string))) || last >= int64(iptolong(subnetInfo["end_addr"].(string))) {
+				return diag.Errorf("Unable to create IP address range: %s, range is out of network's range\n", d.Get("name").(string))
+			}
+
+			if poolInfo != nil && (first < int64(iptolong(poolInfo["start_addr"].(string))) || last > int64(iptolong(poolInfo["end_addr"].(string)))) {
+				return diag.Errorf("Unable to create IP address range: %s, range is out of pool's range\n", d.Get("name").(string))
+			}
+		} else {
+			var rangeErr error = nil
+
+			startAddr, rangeErr = ipaddressfindfreerange(subnetInfo, poolInfo, size, meta)
+
+			if rangeErr != nil {
+				// Reporting a failure
+				return diag.FromErr(rangeErr)
+			}
+		}
+
+		start := iptolong(startAddr)
+		oids := []string{}
+
+		var addErr error = nil
+
+		for i := 0; i < size; i++ {
+			var oid string = ""
+
+			oid, addErr = ipaddressrangeadd(siteID, ipaddressrangename(d.Get("name").(string), i+1), d.Get("class").(string), longtoip(start+uint32(i)), meta)
+
+			if addErr != nil {
+				break
+			}
+
+			oids = append(oids, oid)
+		}
+
+		if addErr == nil {
+			d.SetId(oids[0])
+			return resourceipaddressrangeRead(ctx, d, meta)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Failed IP address range registration for IP address range: %s starting at: %s (%s)\n", d.Get("name").(string), startAddr, addErr))
+
+		// Rolling back the partially registered range
+		for i := len(oids) - 1; i >= 0; i-- {
+			if delErr := ipaddressrangedelete(oids[i], meta); delErr != nil {
+				return diag.Errorf("Unable to create IP address range: %s (%s), rollback failed (%s)\n", d.Get("name").(string), addErr, delErr)
+			}
+		}
+
+		if len(d.Get("request_ip").(string)) > 0 {
+			return diag.FromErr(addErr)
+		}
+	}
+
+	// Reporting a failure
+	return diag.Errorf("Unable to create IP address range: %s, unable to find a suitable network or address range\n", d.Get("name").(string))
+}
+
+func resourceipaddressrangeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oldSize, newSize := d.GetChange("size")
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetInfo == nil || subnetErr != nil {
+		// Reporting a failure
+		if subnetInfo == nil {
+			return diag.Errorf("Unable to update IP address range: %s, unable to find requested network\n", d.Get("name").(string))
+		}
+
+		return diag.FromErr(subnetErr)
+	}
+
+	start := iptolong(d.Get("start_address").(string))
+	span := oldSize.(int)
+
+	if newSize.(int) > span {
+		span = newSize.(int)
+	}
+
+	// The members of the range are named after the previous template
+	oldName, _ := d.GetChange("name")
+	members, others, membersErr := ipaddressrangemembers(subnetInfo["id"].(string), start, span, oldName.(string), meta)
+
+	if membersErr != nil {
+		// Reporting a failure
+		return diag.FromErr(membersErr)
+	}
+
+	// Ensure the addresses required to grow or heal the range are available before registering any of them
+	for i := 0; i < newSize.(int); i++ {
+		if int64(start)+int64(i) >= int64(iptolong(subnetInfo["end_addr"].(string))) {
+			return diag.Errorf("Unable to grow IP address range: %s, range would be out of network's range\n", d.Get("name").(string))
+		}
+		if other, otherExist := others[i]; otherExist {
+			return diag.Errorf("Unable to grow IP address range: %s, address %s is already used by: %s\n", d.Get("name").(string), longtoip(start+uint32(i)), other["name"])
+		}
+	}
+
+	added := []string{}
+
+	// Registering missing addresses and updating existing ones
+	for i := 0; i < newSize.(int); i++ {
+		name := ipaddressrangename(d.Get("name").(string), i+1)
+
+		if member, memberExist := members[i]; memberExist {
+			if d.HasChanges("name", "class") {
+				if editErr := ipaddressrangeedit(member["ip_id"].(string), name, d.Get("class").(string), meta); editErr != nil {
+					return diag.FromErr(editErr)
+				}
+			}
+			continue
+		}
+
+		oid, addErr := ipaddressrangeadd(siteID, name, d.Get("class").(string), longtoip(start+uint32(i)), meta)
+
+		if addErr != nil {
+			// Rolling back the addresses registered to grow the range
+			for j := len(added) - 1; j >= 0; j-- {
+				if delErr := ipaddressrangedelete(added[j], meta); delErr != nil {
+					return diag.Errorf("Unable to grow IP address range: %s (%s), rollback failed (%s)\n", d.Get("name").(string), addErr, delErr)
+				}
+			}
+
+			return diag.FromErr(addErr)
+		}
+
+		added = append(added, oid)
+	}
+
+	// Releasing the addresses beyond the new size, starting from the end of the range
+	for i := span - 1; i >= newSize.(int); i-- {
+		if member, memberExist := members[i]; memberExist {
+			if delErr := ipaddressrangedelete(member["ip_id"].(string), meta); delErr != nil {
+				return diag.FromErr(delErr)
+			}
+		}
+	}
+
+	return resourceipaddressrangeRead(ctx, d, meta)
+}
+
+func resourceipaddressrangeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetInfo == nil || subnetErr != nil {
+		// Reporting a failure
+		if subnetInfo == nil {
+			return diag.Errorf("Unable to delete IP address range: %s, unable to find requested network\n", d.Get("name").(string))
+		}
+
+		return diag.FromErr(subnetErr)
+	}
+
+	members, _, membersErr := ipaddressrangemembers(subnetInfo["id"].(string), iptolong(d.Get("start_address").(string)), d.Get("size").(int), d.Get("name").(string), meta)
+
+	if membersErr != nil {
+		// Reporting a failure
+		return diag.FromErr(membersErr)
+	}
+
+	// Releasing the addresses starting from the end of the range
+	for i := d.Get("size").(int) - 1; i >= 0; i-- {
+		if member, memberExist := members[i]; memberExist {
+			if delErr := ipaddressrangedelete(member["ip_id"].(string), meta); delErr != nil {
+				return diag.Errorf("Unable to delete IP address range: %s (%s)\n", d.Get("name").(string), delErr)
+			}
+		}
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted IP address range's oid: %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipaddressrangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_address_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			start := iptolong(hexiptoip(buf[0]["ip_addr"].(string)))
+			size := d.Get("size").(int)
+
+			members, _, membersErr := ipaddressrangemembers(buf[0]["subnet_id"].(string), start, size, d.Get("name").(string), meta)
+
+			if membersErr != nil {
+				// Reporting a failure
+				return diag.FromErr(membersErr)
+			}
+
+			addresses := []string{}
+
+			for i := 0; i < size; i++ {
+				if _, memberExist := members[i]; memberExist {
+					addresses = append(addresses, longtoip(start+uint32(i)))
+				}
+			}
+
+			d.Set("space", buf[0]["site_name"].(string))
+			ipreferenceset(d, "subnet", buf[0]["subnet_name"].(string))
+			ipreferenceset(d, "pool", buf[0]["pool_name"].(string))
+			d.Set("class", buf[0]["ip_class_name"].(string))
+			d.Set("start_address", longtoip(start))
+			d.Set("end_address", longtoip(start+uint32(size)-1))
+			d.Set("addresses", addresses)
+
+			// Missing addresses are left out of the addresses to be registered again
+			if len(addresses) != size {
+				tflog.Debug(ctx, fmt.Sprintf("IP address range: %s has %d missing address(es)\n", d.Get("name").(string), size-len(addresses)))
+			}
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address range: %s (%s)\n", d.Get("name"), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address range (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP address range: %s\n", d.Get("name").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourceipaddressrangeImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("ip_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_address_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			subnetInfo, subnetErr := ipsubnetinfobyname(buf[0]["site_id"].(string), buf[0]["subnet_name"].(string), true, meta)
+
+			if subnetInfo == nil || subnetErr != nil {
+				return nil, fmt.Errorf("SOLIDServer - Unable to import IP address range (oid): %s, unable to find its network\n", d.Id())
+			}
+
+			// The range is made of the consecutive addresses registered from the imported one and named after its template
+			start := iptolong(hexiptoip(buf[0]["ip_addr"].(string)))
+			addresses, listErr := ipaddressrangelist(subnetInfo["id"].(string), buf[0]["ip_addr"].(string), subnetInfo["end_hex_addr"].(string), meta)
+
+			if listErr != nil {
+				return nil, listErr
+			}
+
+			second := ""
+
+			if len(addresses) > 1 && entrystring(addresses[1], "ip_addr") == iptohexip(longtoip(start+1)) {
+				second = entrystring(addresses[1], "name")
+			}
+
+			template := ipaddressrangetemplate(buf[0]["name"].(string), second)
+
+			if template == "" {
+				return nil, fmt.Errorf("SOLIDServer - Unable to import IP address range (oid): %s, unable to find the position of the address within its name: %s\n", d.Id(), buf[0]["name"].(string))
+			}
+
+			size := 0
+
+			for _, addr := range addresses {
+				name, _ := addr["name"].(string)
+
+				if hexAddr, hexAddrExist := addr["ip_addr"].(string); hexAddrExist && iptolong(hexiptoip(hexAddr)) == start+uint32(size) && name == ipaddressrangename(template, size+1) {
+					size++
+				} else {
+					break
+				}
+			}
+
+			d.Set("space", buf[0]["site_name"].(string))
+			d.Set("subnet", buf[0]["subnet_name"].(string))
+			d.Set("pool", buf[0]["pool_name"].(string))
+			d.Set("name", template)
+			d.Set("class", buf[0]["ip_class_name"].(string))
+			d.Set("size", size)
+			d.Set("start_address", longtoip(start))
+			d.Set("end_address", longtoip(start+uint32(size)-1))
+
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import IP address range (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import IP address range (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import IP address range (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
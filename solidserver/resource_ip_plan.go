@@ -0,0 +1,598 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func resourceipplan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipplanCreate,
+		ReadContext:   resourceipplanRead,
+		UpdateContext: resourceipplanUpdate,
+		DeleteContext: resourceipplanDelete,
+		CustomizeDiff: resourceipplanCustomizeDiff,
+
+		Description: heredoc.Doc(`
+			IP plan allows to carve an IP block into several terminal IP subnets of different sizes at once.
+			The subnets are allocated deterministically, largest first, each one taking the lowest aligned prefix available.
+			Adding a subnet to the plan keeps the existing ones untouched.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the IP subnets.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP block/subnet into which creating the IP subnets.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeList,
+				Description: "The IP subnets of the plan, identified by their name.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IP subnet, unique within the plan.",
+							Required:    true,
+						},
+						"prefix_size": {
+							Type:         schema.TypeInt,
+							Description:  "The expected IP subnet's prefix length (ex: 24 for a '/24'), changing it, or resizing the IP subnet outside of terraform, allocates the IP subnet again unless deletion_protection is set.",
+							ValidateFunc: validation.IntBetween(1, 32),
+							Required:     true,
+						},
+						"gateway_offset": {
+							Type:        schema.TypeInt,
+							Description: "Offset for creating the gateway, a negative offset is counted backward from the end of the subnet. Default is 0 (No gateway).",
+							Optional:    true,
+							Default:     0,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the IP subnet.",
+							Optional:    true,
+							Default:     "",
+						},
+					},
+				},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the IP plan, or to delete any of its IP subnets, while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"prefixes": {
+				Type:        schema.TypeMap,
+				Description: "The provisionned IP prefix of each IP subnet, indexed by name.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"gateways": {
+				Type:        schema.TypeMap,
+				Description: "The computed gateway of each IP subnet having a gateway_offset, indexed by name.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"subnet_ids": {
+				Type:        schema.TypeMap,
+				Description: "The oid of each IP subnet, indexed by name.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Return the subnets of an IP plan indexed by name
+func ipplanentries(subnets interface{}) map[string]map[string]interface{} {
+	entries := map[string]map[string]interface{}{}
+
+	for _, s := range subnets.([]interface{}) {
+		entry := s.(map[string]interface{})
+		entries[entry["name"].(string)] = entry
+	}
+
+	return entries
+}
+
+// Allocate the prefixes of the new subnets of an IP plan within its block, largest first
+// Each subnet takes the lowest aligned prefix that doesn't overlap the used ranges nor the previously allocated ones
+// Return the network address of each subnet, indexed by name
+func ipplanallocate(blockStart uint32, blockEnd uint32, used [][2]uint32, entries []map[string]interface{}) (map[string]uint32, error) {
+	res := map[string]uint32{}
+
+	// Sorting the subnets by decreasing size, keeping the order of the plan for the subnets of the same size
+	sorted := append([]map[string]interface{}{}, entries...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i]["prefix_size"].(int) < sorted[j]["prefix_size"].(int)
+	})
+
+	for _, entry := range sorted {
+		prefixSize := entry["prefix_size"].(int)
+		size := uint64(prefixlengthtosize(prefixSize))
+		found := false
+
+		// Starting from the first address of the block aligned on the size of the subnet
+		for start := (uint64(blockStart) + size - 1) / size * size; start+size-1 <= uint64(blockEnd); start += size {
+			overlap := false
+
+			for _, r := range used {
+				if start <= uint64(r[1]) && start+size-1 >= uint64(r[0]) {
+					overlap = true
+					break
+				}
+			}
+
+			if !overlap {
+				res[entry["name"].(string)] = uint32(start)
+				used = append(used, [2]uint32{uint32(start), uint32(start + size - 1)})
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no /%d prefix left for IP subnet: %s", prefixSize, entry["name"].(string))
+		}
+	}
+
+	return res, nil
+}
+
+// Build the class parameters of a subnet of an IP plan from its current ones, setting or dropping its gateway
+func ipplanclassparameters(current map[string]string, gateway string) url.Values {
+	classParameters := url.Values{}
+
+	for ck, cv := range current {
+		if ck != "gateway" {
+			classParameters.Add(ck, cv)
+		}
+	}
+
+	if gateway != "" {
+		classParameters.Add("gateway", gateway)
+	} else if current["gateway"] != "" {
+		classParameters.Add("gateway", "")
+	}
+
+	return classParameters
+}
+
+// Return the sorted names of the provisioned subnets of an IP plan that must be deleted
+// They are removed from the plan, or their prefix length differs from the expected one, including when resized outside of terraform
+func ipplanreleasing(subnetIDs map[string]string, prefixes map[string]string, entries map[string]map[string]interface{}) []string {
+	res := []string{}
+
+	for name := range subnetIDs {
+		entry, entryExist := entries[name]
+		prefix := strings.Split(prefixes[name], "/")
+
+		if entryExist && len(prefix) == 2 && prefix[1] == strconv.Itoa(entry["prefix_size"].(int)) {
+			continue
+		}
+
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// Delete a subnet of an IP plan along with its gateway
+func ipplanrelease(space string, name string, subnetID string, gateway string, meta interface{}) error {
+	if gateway != "" {
+		if err := subnetgatewayrelease(space, gateway, false, meta); err != nil {
+			return err
+		}
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", subnetID)
+
+	if err := objectdelete("rest/ip_subnet_delete", &parameters, meta); err != nil {
+		return fmt.Errorf("Unable to delete IP subnet: %s (%s)\n", name, err)
+	}
+
+	return nil
+}
+
+// Validate at plan time that the subnets of the IP plan are uniquely named and that their gateway fits
+func resourceipplanCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("subnet") {
+		return nil
+	}
+
+	names := map[string]bool{}
+	subnetIDs := map[string]string{}
+	prefixes := map[string]string{}
+	missing := false
+
+	for name, id := range d.Get("subnet_ids").(map[string]interface{}) {
+		subnetIDs[name] = id.(string)
+		prefixes[name], _ = d.Get("prefixes").(map[string]interface{})[name].(string)
+	}
+
+	for _, s := range d.Get("subnet").([]interface{}) {
+		entry := s.(map[string]interface{})
+		name := entry["name"].(string)
+
+		if names[name] {
+			return fmt.Errorf("Unable to create IP plan: IP subnet name %s is used more than once\n", name)
+		}
+
+		names[name] = true
+
+		// Ensure the gateway stands within the subnet, excluding its network and broadcast addresses
+		if goffset, subnetSize := entry["gateway_offset"].(int), prefixlengthtosize(entry["prefix_size"].(int)); goffset != 0 && abs(goffset) > subnetSize-2 {
+			return fmt.Errorf("Unable to create IP plan: IP subnet %s, gateway_offset %d is beyond the size of a /%d subnet\n", name, goffset, entry["prefix_size"].(int))
+		}
+
+		if _, idExist := subnetIDs[name]; !idExist {
+			missing = true
+		}
+	}
+
+	// Subnets removed from the plan, changing size or resized outside of terraform are deleted
+	releasing := ipplanreleasing(subnetIDs, prefixes, ipplanentries(d.Get("subnet")))
+
+	if len(releasing) != 0 && d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("Unable to update IP plan within block: %s, deletion protection is enabled, IP subnet(s) %s would be deleted\n", d.Get("block").(string), strings.Join(releasing, ", "))
+	}
+
+	// Changing the plan, or recreating the subnets deleted or resized outside of terraform, changes its computed attributes
+	if d.Id() != "" && (d.HasChange("subnet") || missing || len(releasing) != 0) {
+		for _, key := range []string{"prefixes", "gateways", "subnet_ids"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reconcile the subnets of an IP plan with its expected subnets
+// Subnets removed from the plan, or changing size, are released first, then the new ones are allocated around the remaining ones
+// Subnets are only released while the deletion protection is disabled
+func resourceipplanapply(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	oldSubnets, newSubnets := d.GetChange("subnet")
+	oldEntries := ipplanentries(oldSubnets)
+	newEntries := ipplanentries(newSubnets)
+
+	oldPrefixes, _ := d.GetChange("prefixes")
+	s := meta.(*SOLIDserver)
+	oldGateways, _ := d.GetChange("gateways")
+	oldSubnetIDs, _ := d.GetChange("subnet_ids")
+
+	prefixes := map[string]string{}
+	gateways := map[string]string{}
+	subnetIDs := map[string]string{}
+
+	for name, id := range oldSubnetIDs.(map[string]interface{}) {
+		subnetIDs[name] = id.(string)
+		prefixes[name], _ = oldPrefixes.(map[string]interface{})[name].(string)
+		gateways[name], _ = oldGateways.(map[string]interface{})[name].(string)
+	}
+
+	saveState := func() {
+		d.Set("prefixes", prefixes)
+		d.Set("gateways", gateways)
+		d.Set("subnet_ids", subnetIDs)
+	}
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(space, meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blockErr)
+	}
+
+	// Releasing the subnets removed from the plan or changing size
+	releasing := ipplanreleasing(subnetIDs, prefixes, newEntries)
+
+	if len(releasing) != 0 && d.Get("deletion_protection").(bool) {
+		saveState()
+		return diag.Errorf("Unable to update IP plan within block: %s, deletion protection is enabled, IP subnet(s) %s would be deleted\n", d.Get("block").(string), strings.Join(releasing, ", "))
+	}
+
+	for _, name := range releasing {
+		subnetID := subnetIDs[name]
+
+		if err := ipplanrelease(space, name, subnetID, gateways[name], meta); err != nil {
+			saveState()
+			return diag.FromErr(err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleted IP plan's subnet: %s (%s)\n", name, prefixes[name]))
+
+		delete(subnetIDs, name)
+		delete(prefixes, name)
+		delete(gateways, name)
+	}
+
+	// Updating the class and gateway of the remaining subnets in place
+	for name, subnetID := range subnetIDs {
+		newEntry := newEntries[name]
+		oldEntry := oldEntries[name]
+
+		if oldEntry == nil || (newEntry["class"].(string) == oldEntry["class"].(string) && newEntry["gateway_offset"].(int) == oldEntry["gateway_offset"].(int)) {
+			continue
+		}
+
+		address := strings.Split(prefixes[name], "/")[0]
+		gateway := ""
+
+		if goffset := newEntry["gateway_offset"].(int); goffset != 0 {
+			gateway = subnetaddressatoffset(address, newEntry["prefix_size"].(int), goffset, false)
+		}
+
+		if gateways[name] != "" && gateways[name] != gateway {
+			if err := subnetgatewayrelease(space, gateways[name], false, meta); err != nil {
+				saveState()
+				return diag.FromErr(err)
+			}
+		}
+
+		// Retrieving the current class parameters of the subnet, only its gateway is managed by the plan
+		infoParameters := url.Values{}
+		infoParameters.Add("subnet_id", subnetID)
+
+		resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &infoParameters)
+
+		if err != nil {
+			saveState()
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if resp.StatusCode != 200 || len(buf) == 0 {
+			saveState()
+			return diag.Errorf("Unable to update IP subnet: %s, unable to retrieve its class parameters\n", name)
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("subnet_id", subnetID)
+		parameters.Add("add_flag", "edit_only")
+		parameters.Add("subnet_name", name)
+		parameters.Add("subnet_class_name", newEntry["class"].(string))
+		parameters.Add("subnet_class_parameters", ipplanclassparameters(entryclassparameters(buf[0], "subnet_class_parameters"), gateway).Encode())
+
+		if _, err := objectadd("rest/ip_subnet_add", &parameters, meta); err != nil {
+			saveState()
+			return diag.Errorf("Unable to update IP subnet: %s (%s)\n", name, err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Updated IP plan's subnet: %s (%s)\n", name, prefixes[name]))
+
+		gateways[name] = gateway
+
+		if gateway == "" {
+			delete(gateways, name)
+		}
+	}
+
+	// Allocating the new subnets around the ones already standing within the block
+	pending := []map[string]interface{}{}
+
+	for _, s := range newSubnets.([]interface{}) {
+		entry := s.(map[string]interface{})
+
+		if _, idExist := subnetIDs[entry["name"].(string)]; !idExist {
+			pending = append(pending, entry)
+		}
+	}
+
+	if len(pending) != 0 {
+		children, err := objectlistbyquery("rest/ip_block_subnet_list", "parent_subnet_id='"+blockInfo["id"].(string)+"'", "", "", 0, meta)
+
+		if err != nil {
+			saveState()
+			return diag.FromErr(err)
+		}
+
+		used := [][2]uint32{}
+
+		for _, child := range children {
+			used = append(used, [2]uint32{iptolong(hexiptoip(entrystring(child, "start_ip_addr"))), iptolong(hexiptoip(entrystring(child, "end_ip_addr")))})
+		}
+
+		addresses, err := ipplanallocate(iptolong(blockInfo["start_addr"].(string)), iptolong(blockInfo["end_addr"].(string)), used, pending)
+
+		if err != nil {
+			saveState()
+			return diag.Errorf("Unable to create IP plan within block: %s, %s\n", d.Get("block").(string), err)
+		}
+
+		subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
+
+		for _, entry := range pending {
+			name := entry["name"].(string)
+			address := longtoip(addresses[name])
+			prefixSize := entry["prefix_size"].(int)
+			gateway := ""
+
+			if goffset := entry["gateway_offset"].(int); goffset != 0 {
+				gateway = subnetaddressatoffset(address, prefixSize, goffset, false)
+			}
+
+			// Building parameters
+			parameters := url.Values{}
+			parameters.Add("site_id", siteID)
+			parameters.Add("add_flag", "new_only")
+			parameters.Add("subnet_name", name)
+			parameters.Add("subnet_addr", address)
+			parameters.Add("subnet_prefix", strconv.Itoa(prefixSize))
+			parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
+			parameters.Add("is_terminal", "1")
+			parameters.Add("subnet_class_name", entry["class"].(string))
+			parameters.Add("subnet_class_parameters", ipplanclassparameters(nil, gateway).Encode())
+
+			oid, err := objectadd("rest/ip_subnet_add", &parameters, meta)
+
+			if err != nil {
+				saveState()
+				return diag.Errorf("Unable to create IP subnet: %s with prefix: %s/%d (%s)\n", name, address, prefixSize, err)
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Created IP plan's subnet: %s (oid): %s\n", name, oid))
+
+			subnetIDs[name] = oid
+			prefixes[name] = address + "/" + strconv.Itoa(prefixSize)
+
+			if gateway != "" {
+				gateways[name] = gateway
+			}
+		}
+	}
+
+	saveState()
+
+	return nil
+}
+
+func resourceipplanCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blockErr)
+	}
+
+	// The IP plan is identified by its block, the subnets created so far are kept in the state on failure
+	d.SetId(blockInfo["id"].(string))
+
+	return resourceipplanapply(ctx, d, meta)
+}
+
+func resourceipplanUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("subnet", "prefixes", "subnet_ids") {
+		return resourceipplanapply(ctx, d, meta)
+	}
+
+	return nil
+}
+
+func resourceipplanDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Unable to delete IP plan within block: %s, deletion protection is enabled\n", d.Get("block").(string))
+	}
+
+	subnetIDs := d.Get("subnet_ids").(map[string]interface{})
+	gateways := d.Get("gateways").(map[string]interface{})
+
+	for name, subnetID := range subnetIDs {
+		gateway, _ := gateways[name].(string)
+
+		if err := ipplanrelease(d.Get("space").(string), name, subnetID.(string), gateway, meta); err != nil {
+			// Keeping the remaining subnets in the state
+			d.Set("subnet_ids", subnetIDs)
+			return diag.FromErr(err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleted IP plan's subnet: %s (oid): %s\n", name, subnetID.(string)))
+
+		delete(subnetIDs, name)
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipplanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	prefixes := map[string]string{}
+	gateways := map[string]string{}
+	subnetIDs := map[string]string{}
+
+	for name, subnetID := range d.Get("subnet_ids").(map[string]interface{}) {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("subnet_id", subnetID.(string))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// The subnets deleted outside of terraform are allocated again
+		if resp.StatusCode != 200 || len(buf) == 0 {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP plan's subnet: %s (oid): %s\n", name, subnetID.(string)))
+			continue
+		}
+
+		subnetSize, _ := strconv.Atoi(entrystring(buf[0], "subnet_size"))
+		subnetIDs[name] = subnetID.(string)
+		prefixes[name] = hexiptoip(entrystring(buf[0], "start_ip_addr")) + "/" + strconv.Itoa(sizetoprefixlength(subnetSize))
+
+		if gateway, gatewayExist := entryclassparameters(buf[0], "subnet_class_parameters")["gateway"]; gatewayExist && gateway != "" {
+			gateways[name] = gateway
+		}
+	}
+
+	d.Set("prefixes", prefixes)
+	d.Set("gateways", gateways)
+	d.Set("subnet_ids", subnetIDs)
+
+	return nil
+}
@@ -20,6 +20,7 @@ func resourceippool() *schema.Resource {
 		ReadContext:   resourceippoolRead,
 		UpdateContext: resourceippoolUpdate,
 		DeleteContext: resourceippoolDelete,
+		CustomizeDiff: resourceippoolCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceippoolImportState,
 		},
@@ -48,13 +49,13 @@ func resourceippool() *schema.Resource {
 				Description:  "The IP pool lower IP address.",
 				ValidateFunc: validation.IsIPAddress,
 				Required:     true,
-				ForceNew:     true,
+				ForceNew:     false,
 			},
 			"size": {
 				Type:        schema.TypeInt,
 				Description: "The size of the IP pool to create.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"dhcp_range": {
 				Type:        schema.TypeBool,
@@ -63,6 +64,20 @@ func resourceippool() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IP pool is read-only, preventing any address allocation within it (Default: false). A DHCP range pool is always read-only.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"reserved": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IP pool is reserved, keeping its addresses out of the automatic address allocation (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Description: "The name of the IP pool to create.",
@@ -95,10 +110,87 @@ func resourceippool() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IP pool, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IP pool, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_propagation": {
+				Type:         schema.TypeMap,
+				Description:  "The propagation property of the class parameters associated to the IP pool, as a map of class parameter name to 'propagate' (value pushed down to the child objects) or 'restrict' (value kept local).",
+				ValidateFunc: resourceclassparamspropagationvalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested range fits within the parent subnet
+func resourceippoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only check new or moving IP pools, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "subnet", "start", "size") {
+		return nil
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("subnet") || !d.NewValueKnown("start") || !d.NewValueKnown("size") {
+		return nil
+	}
+
+	if d.Get("size").(int) <= 0 {
+		return fmt.Errorf("Unable to create IP pool: %s, invalid size: %d\n", d.Get("name").(string), d.Get("size").(int))
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP pool: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil || subnetInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP pool: %s, unable to find subnet: %s\n", d.Get("name").(string), d.Get("subnet").(string)))
+		return nil
+	}
+
+	poolStart := iptolong(d.Get("start").(string))
+	poolEnd := poolStart + uint32(d.Get("size").(int)) - 1
+
+	if poolEnd < poolStart || poolStart < iptolong(subnetInfo["start_addr"].(string)) || poolEnd > iptolong(subnetInfo["end_addr"].(string)) {
+		return fmt.Errorf("Unable to create IP pool: %s, range %s - %s is out of network's range (%s - %s)\n", d.Get("name").(string), longtoip(poolStart), longtoip(poolEnd), subnetInfo["start_addr"].(string), subnetInfo["end_addr"].(string))
+	}
+
+	return nil
+}
+
 func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -128,20 +220,32 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	// Building class_parameters
 	classParameters := url.Values{}
 
-	// Generate class parameter for dhcp range sync
+	// Generate class parameter for dhcp range sync, a DHCP range pool is always read-only
 	if d.Get("dhcp_range").(bool) {
 		parameters.Add("pool_read_only", "1")
 		classParameters.Add("dhcprange", "1")
 	} else {
-		parameters.Add("pool_read_only", "0")
+		if d.Get("read_only").(bool) {
+			parameters.Add("pool_read_only", "1")
+		} else {
+			parameters.Add("pool_read_only", "0")
+		}
+
 		classParameters.Add("dhcprange", "0")
 	}
 
+	if d.Get("reserved").(bool) {
+		parameters.Add("pool_reserved", "1")
+	} else {
+		parameters.Add("pool_reserved", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
 
 	parameters.Add("pool_class_parameters", classParameters.Encode())
+	parameters.Add("pool_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the creation request
 	resp, body, err := s.Request("post", "rest/ip_pool_add", &parameters)
@@ -187,23 +291,49 @@ func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	parameters.Add("pool_name", d.Get("name").(string))
 	parameters.Add("pool_class_name", d.Get("class").(string))
 
+	// Moving or resizing the IP pool in place, its allocated addresses must remain within the new range
+	if d.HasChanges("start", "size") {
+		poolStart := iptolong(d.Get("start").(string))
+		poolEnd := poolStart + uint32(d.Get("size").(int)) - 1
+
+		if err := ippoolmovecheck(d.Id(), iptohexip(longtoip(poolStart)), iptohexip(longtoip(poolEnd)), false, meta); err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		parameters.Add("start_addr", d.Get("start").(string))
+		parameters.Add("pool_size", strconv.Itoa(d.Get("size").(int)))
+	}
+
 	// Building class_parameters
 	classParameters := url.Values{}
 
-	// Generate class parameter for dhcp range sync
+	// Generate class parameter for dhcp range sync, a DHCP range pool is always read-only
 	if d.Get("dhcp_range").(bool) {
 		parameters.Add("pool_read_only", "1")
 		classParameters.Add("dhcprange", "1")
 	} else {
-		parameters.Add("pool_read_only", "0")
+		if d.Get("read_only").(bool) {
+			parameters.Add("pool_read_only", "1")
+		} else {
+			parameters.Add("pool_read_only", "0")
+		}
+
 		classParameters.Add("dhcprange", "0")
 	}
 
+	if d.Get("reserved").(bool) {
+		parameters.Add("pool_reserved", "1")
+	} else {
+		parameters.Add("pool_reserved", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
 
 	parameters.Add("pool_class_parameters", classParameters.Encode())
+	parameters.Add("pool_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_pool_add", &parameters)
@@ -294,6 +424,23 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 			d.Set("name", buf[0]["pool_name"].(string))
 			d.Set("class", buf[0]["pool_class_name"].(string))
 
+			if start, startExist := buf[0]["start_ip_addr"].(string); startExist {
+				d.Set("start", hexiptoip(start))
+			}
+
+			if size, sizeExist := buf[0]["pool_size"].(string); sizeExist {
+				if poolSize, poolSizeErr := strconv.Atoi(size); poolSizeErr == nil {
+					d.Set("size", poolSize)
+				}
+			}
+
+			// A DHCP range pool is always read-only, only track the flag when set on its own
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool_read_only"] == "1")
+			}
+
+			d.Set("reserved", buf[0]["pool_reserved"] == "1")
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool_class_parameters"].(string))
@@ -316,6 +463,14 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["pool_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return nil
 		}
@@ -359,6 +514,23 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 			d.Set("name", buf[0]["pool_name"].(string))
 			d.Set("class", buf[0]["pool_class_name"].(string))
 
+			if start, startExist := buf[0]["start_ip_addr"].(string); startExist {
+				d.Set("start", hexiptoip(start))
+			}
+
+			if size, sizeExist := buf[0]["pool_size"].(string); sizeExist {
+				if poolSize, poolSizeErr := strconv.Atoi(size); poolSizeErr == nil {
+					d.Set("size", poolSize)
+				}
+			}
+
+			// A DHCP range pool is always read-only, only track the flag when set on its own
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool_read_only"] == "1")
+			}
+
+			d.Set("reserved", buf[0]["pool_reserved"] == "1")
+
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["pool_class_parameters"].(string))
@@ -381,6 +553,14 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["pool_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -32,7 +32,21 @@ func resourceipspace() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The name of the IP space to create.",
 				Required:    true,
+				ForceNew:    false,
+			},
+			"parent_space": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP space within the space hierarchy (VLSM), the IP space is created at the top of the hierarchy if not set.",
+				Optional:    true,
 				ForceNew:    true,
+				Default:     "",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the IP space while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
 			},
 			"class": {
 				Type:        schema.TypeString,
@@ -50,6 +64,33 @@ func resourceipspace() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to IP space, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_propagation": {
+				Type:         schema.TypeMap,
+				Description:  "The propagation property of the class parameters associated to the IP space, as a map of class parameter name to 'propagate' (value pushed down to the child objects) or 'restrict' (value kept local).",
+				ValidateFunc: resourceclassparamspropagationvalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -61,8 +102,21 @@ func resourceipspaceCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters := url.Values{}
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("site_name", d.Get("name").(string))
+
+	// Attaching the IP space to its parent within the space hierarchy if required
+	if d.Get("parent_space").(string) != "" {
+		parentID, parentErr := ipsiteidbyname(d.Get("parent_space").(string), meta)
+
+		if parentErr != nil || parentID == "" {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IP space: %s, unable to find parent IP space: %s\n", d.Get("name").(string), d.Get("parent_space").(string))
+		}
+
+		parameters.Add("parent_site_id", parentID)
+	}
 	parameters.Add("site_class_name", d.Get("class").(string))
 	parameters.Add("site_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("site_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_propagation")).Encode())
 
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/ip_site_add", &parameters)
@@ -105,6 +159,7 @@ func resourceipspaceUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("site_name", d.Get("name").(string))
 	parameters.Add("site_class_name", d.Get("class").(string))
 	parameters.Add("site_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+	parameters.Add("site_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_site_add", &parameters)
@@ -139,6 +194,14 @@ func resourceipspaceUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourceipspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Refusing to delete a protected or non empty IP space
+	if err := deletionprotectioncheck(d, "IP space", map[string]string{
+		"ip_block_subnet_count":    "site_id='" + d.Id() + "'",
+		"ip6_block6_subnet6_count": "site_id='" + d.Id() + "'",
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("site_id", d.Id())
@@ -193,6 +256,7 @@ func resourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("name", buf[0]["site_name"].(string))
+			d.Set("parent_space", ipsiteparentname(buf[0]))
 			d.Set("class", buf[0]["site_class_name"].(string))
 
 			// Updating local class_parameters
@@ -209,6 +273,13 @@ func resourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["site_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return nil
 		}
@@ -250,6 +321,7 @@ func resourceipspaceImportState(ctx context.Context, d *schema.ResourceData, met
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("name", buf[0]["site_name"].(string))
+			d.Set("parent_space", ipsiteparentname(buf[0]))
 			d.Set("class", buf[0]["site_class_name"].(string))
 
 			// Updating local class_parameters
@@ -266,6 +338,13 @@ func resourceipspaceImportState(ctx context.Context, d *schema.ResourceData, met
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["site_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -21,6 +21,7 @@ func resourceipsubnet() *schema.Resource {
 		ReadContext:   resourceipsubnetRead,
 		UpdateContext: resourceipsubnetUpdate,
 		DeleteContext: resourceipsubnetDelete,
+		CustomizeDiff: resourceipsubnetCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceipsubnetImportState,
 		},
@@ -45,6 +46,33 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    true,
 				Default:     "",
 			},
+			"block_query": {
+				Type:          schema.TypeString,
+				Description:   "The query used to select the candidate parent IP blocks/subnets, tried in order until the expected prefix is available (Alternative to block).",
+				Optional:      true,
+				ForceNew:      true,
+				Default:       "",
+				ConflictsWith: []string{"block"},
+			},
+			"block_tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to select the candidate parent IP blocks/subnets in the block_query.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"block_orderby": {
+				Type:        schema.TypeString,
+				Description: "The order in which the candidate parent IP blocks/subnets selected by the block_query are tried.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"selected_block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP block/subnet into which the IP subnet was created.",
+				Computed:    true,
+			},
 			"request_ip": {
 				Type:         schema.TypeString,
 				Description:  "The optionally requested subnet IP address.",
@@ -54,10 +82,11 @@ func resourceipsubnet() *schema.Resource {
 				Default:      "",
 			},
 			"prefix_size": {
-				Type:        schema.TypeInt,
-				Description: "The expected IP subnet's prefix length (ex: 24 for a '/24').",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeInt,
+				Description:  "The expected IP subnet's prefix length (ex: 24 for a '/24').",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+				ForceNew:     false,
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -74,20 +103,108 @@ func resourceipsubnet() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The provisionned IP address netmask.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"gateway_offset": {
 				Type:        schema.TypeInt,
-				Description: "Offset for creating the gateway. Default is 0 (No gateway).",
+				Description: "Offset for creating the gateway, a negative offset is counted backward from the end of the subnet. Default is 0 (No gateway).",
 				Optional:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 				Default:     0,
 			},
 			"gateway": {
 				Type:        schema.TypeString,
 				Description: "The subnet's computed gateway.",
 				Computed:    true,
-				ForceNew:    true,
+			},
+			"gateway_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the gateway's IP address (Default: SOLIDserver's naming), the FQDN of its DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the gateway's IP address.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the gateway's IP address.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"gateway_dns_server": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name, hosting the gateway's DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_dns_view": {
+				Type:        schema.TypeString,
+				Description: "The View name of the gateway's DNS records.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"gateway_dns_forward": {
+				Type:         schema.TypeBool,
+				Description:  "Create the forward DNS record (A) of the gateway, named after gateway_name (Default: false).",
+				Optional:     true,
+				ForceNew:     false,
+				Default:      false,
+				RequiredWith: []string{"gateway_name", "gateway_dns_server"},
+			},
+			"gateway_dns_reverse": {
+				Type:         schema.TypeBool,
+				Description:  "Create the reverse DNS record (PTR) of the gateway, pointing to gateway_name (Default: false).",
+				Optional:     true,
+				ForceNew:     false,
+				Default:      false,
+				RequiredWith: []string{"gateway_name", "gateway_dns_server"},
+			},
+			"reserved_addresses": {
+				Type:        schema.TypeList,
+				Description: "The addresses reserved at fixed offsets within the IP subnet (HSRP/VRRP, infrastructure, ...), created along with it.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"offset": {
+							Type:        schema.TypeInt,
+							Description: "Offset of the reserved address within the subnet, a negative offset is counted backward from the end of the subnet.",
+							Required:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the reserved address.",
+							Required:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the reserved address.",
+							Optional:    true,
+							Default:     "",
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC Address of the reserved address.",
+							Optional:    true,
+							Default:     "",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The computed reserved address.",
+							Computed:    true,
+						},
+					},
+				},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -102,6 +219,38 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    true,
 				Default:     true,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Description: "Refuse to destroy the IP subnet while this flag is set (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
+			},
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain of the VLAN associated to the IP subnet, requires either vlan or vlan_id.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"vlan_id": {
+				Type:          schema.TypeInt,
+				Description:   "The VLAN ID of the VLAN associated to the IP subnet.",
+				Optional:      true,
+				ForceNew:      false,
+				Default:       0,
+				RequiredWith:  []string{"vlan_domain"},
+				ConflictsWith: []string{"vlan"},
+			},
+			"vlan": {
+				Type:          schema.TypeString,
+				Description:   "The name of the VLAN associated to the IP subnet (Alternative to vlan_id).",
+				Optional:      true,
+				ForceNew:      false,
+				Default:       "",
+				RequiredWith:  []string{"vlan_domain"},
+				ConflictsWith: []string{"vlan_id"},
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP subnet.",
@@ -118,12 +267,163 @@ func resourceipsubnet() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to the IP subnet, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_inheritance": {
+				Type:         schema.TypeMap,
+				Description:  "The inheritance property of the class parameters associated to the IP subnet, as a map of class parameter name to 'inherit' (value inherited from the parent object) or 'set' (value set locally).",
+				ValidateFunc: resourceclassparamsinheritancevalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"class_parameters_propagation": {
+				Type:         schema.TypeMap,
+				Description:  "The propagation property of the class parameters associated to the IP subnet, as a map of class parameter name to 'propagate' (value pushed down to the child objects) or 'restrict' (value kept local).",
+				ValidateFunc: resourceclassparamspropagationvalidate,
+				Optional:     true,
+				ForceNew:     false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
+// Validate at plan time that the requested prefix, gateway and reserved addresses fit within the parent block
+func resourceipsubnetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := subnetvlancheck(d); err != nil {
+		return err
+	}
+
+	// Only check new or moving IP subnets, once all the required information is known
+	if d.Id() != "" && !d.HasChanges("space", "block", "request_ip", "prefix_size", "gateway_offset", "reserved_addresses", "terminal") {
+		return nil
+	}
+
+	// Resizing an existing IP subnet in place or moving its gateway changes its computed attributes
+	if d.Id() != "" && d.HasChanges("prefix_size", "gateway_offset") {
+		computed := []string{}
+
+		if d.HasChange("prefix_size") {
+			computed = append(computed, "prefix", "netmask")
+		}
+
+		if d.HasChange("gateway_offset") || d.Get("gateway_offset").(int) != 0 {
+			computed = append(computed, "gateway")
+		}
+
+		for _, key := range computed {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.NewValueKnown("prefix_size") || !d.NewValueKnown("gateway_offset") || !d.NewValueKnown("request_ip") {
+		return nil
+	}
+
+	prefixSize := d.Get("prefix_size").(int)
+	subnetSize := prefixlengthtosize(prefixSize)
+
+	if subnetSize == -1 {
+		return fmt.Errorf("Unable to create IP subnet: %s, invalid prefix size: /%d\n", d.Get("name").(string), prefixSize)
+	}
+
+	// Ensure the gateway stands within the subnet, excluding its network and broadcast addresses
+	if goffset := d.Get("gateway_offset").(int); goffset != 0 && abs(goffset) > subnetSize-2 {
+		return fmt.Errorf("Unable to create IP subnet: %s, gateway_offset %d is beyond the size of a /%d subnet\n", d.Get("name").(string), goffset, prefixSize)
+	}
+
+	// Ensure the reserved addresses stand within the subnet, apart from the gateway
+	if d.NewValueKnown("reserved_addresses") {
+		reserved := d.Get("reserved_addresses").([]interface{})
+
+		if err := subnetreservedcheck(reserved, d.Get("gateway_offset").(int)); err != nil {
+			return fmt.Errorf("Unable to create IP subnet: %s, %s\n", d.Get("name").(string), err)
+		}
+
+		for _, r := range reserved {
+			if offset := r.(map[string]interface{})["offset"].(int); abs(offset) > subnetSize-2 {
+				return fmt.Errorf("Unable to create IP subnet: %s, reserved address offset %d is beyond the size of a /%d subnet\n", d.Get("name").(string), offset, prefixSize)
+			}
+		}
+	}
+
+	// Ensure the requested IP address is the network address of the expected prefix
+	if requestedIP := d.Get("request_ip").(string); len(requestedIP) > 0 && !ipisnetworkaddress(requestedIP, prefixSize) {
+		return fmt.Errorf("Unable to create IP subnet: %s, %s is not a valid network address for a /%d prefix\n", d.Get("name").(string), requestedIP, prefixSize)
+	}
+
+	if !d.NewValueKnown("space") || !d.NewValueKnown("block") || !d.NewValueKnown("block_query") || !d.NewValueKnown("terminal") {
+		return nil
+	}
+
+	// Candidate blocks are only known at apply time when using a block_query
+	if len(d.Get("block_query").(string)) > 0 {
+		return nil
+	}
+
+	if len(d.Get("block").(string)) == 0 {
+		if d.Get("terminal").(bool) {
+			return fmt.Errorf("Can't create a terminal IP block subnet: %s\n", d.Get("name").(string))
+		}
+
+		return nil
+	}
+
+	// Parent objects might be created within the same plan, skip the check if they can't be found yet
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil || siteID == "" {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP subnet: %s, unable to find space: %s\n", d.Get("name").(string), d.Get("space").(string)))
+		return nil
+	}
+
+	blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil || blockInfo == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping plan time validation of IP subnet: %s, unable to find block: %s\n", d.Get("name").(string), d.Get("block").(string)))
+		return nil
+	}
+
+	if blockPrefixLength, blockPrefixLengthExist := blockInfo["prefix_length"].(int); blockPrefixLengthExist && prefixSize < blockPrefixLength {
+		return fmt.Errorf("Unable to create IP subnet: %s, a /%d prefix doesn't fit within block %s (%s/%d)\n", d.Get("name").(string), prefixSize, d.Get("block").(string), blockInfo["start_addr"].(string), blockPrefixLength)
+	}
+
+	if requestedIP := d.Get("request_ip").(string); len(requestedIP) > 0 {
+		requestedStart := iptolong(requestedIP)
+		requestedEnd := requestedStart + uint32(subnetSize) - 1
+
+		if requestedStart < iptolong(blockInfo["start_addr"].(string)) || requestedEnd > iptolong(blockInfo["end_addr"].(string)) {
+			return fmt.Errorf("Unable to create IP subnet: %s, prefix %s/%d is out of block's range (%s - %s)\n", d.Get("name").(string), requestedIP, prefixSize, blockInfo["start_addr"].(string), blockInfo["end_addr"].(string))
+		}
+	}
+
+	return nil
+}
+
 func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	blockInfo := make(map[string]interface{})
+	blockInfos := []map[string]interface{}{}
 	s := meta.(*SOLIDserver)
 	var gateway string = ""
 
@@ -136,18 +436,32 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	// If a block is specified, look for free IP subnet within this block
 	if len(d.Get("block").(string)) > 0 {
+		//blockID, blockErr = ipsubnetidbyname(siteID, d.Get("block").(string), false, meta)
+		blockInfo, blockErr := ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+		if blockErr != nil {
+			// Reporting a failure
+			return diag.FromErr(blockErr)
+		}
+
+		blockInfos = append(blockInfos, blockInfo)
+	} else if len(d.Get("block_query").(string)) > 0 {
+		// If a block query is specified, look for free IP subnet within each matching block
 		var blockErr error = nil
 
-		//blockID, blockErr = ipsubnetidbyname(siteID, d.Get("block").(string), false, meta)
-		blockInfo, blockErr = ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+		blockInfos, blockErr = ipsubnetinfobyquery(siteID, d.Get("block_query").(string), d.Get("block_tags").(string), d.Get("block_orderby").(string), meta)
 
 		if blockErr != nil {
 			// Reporting a failure
 			return diag.FromErr(blockErr)
 		}
+
+		if len(blockInfos) == 0 {
+			return diag.Errorf("Unable to create IP subnet: %s, no block matching the query: %s\n", d.Get("name").(string), d.Get("block_query").(string))
+		}
 	} else {
 		// Otherwise, set an empty blockInfo's ID by default
-		blockInfo["id"] = ""
+		blockInfos = append(blockInfos, map[string]interface{}{"id": "", "name": ""})
 
 		// However, we can't create a block as a terminal subnet
 		if d.Get("terminal").(bool) {
@@ -155,100 +469,134 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
-
-	if subnetErr != nil {
-		// Reporting a failure
-		return diag.FromErr(subnetErr)
-	}
-
-	for i := 0; i < len(subnetAddresses); i++ {
-		// Building parameters
-		parameters := url.Values{}
-		parameters.Add("site_id", siteID)
-		parameters.Add("add_flag", "new_only")
-		parameters.Add("subnet_name", d.Get("name").(string))
-		parameters.Add("subnet_addr", hexiptoip(subnetAddresses[i]))
-		parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
-		parameters.Add("subnet_class_name", d.Get("class").(string))
+	for _, blockInfo := range blockInfos {
+		subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
 
-		// If no block specified, create an IP block
-		if len(d.Get("block").(string)) == 0 {
-			parameters.Add("subnet_level", "0")
-		} else {
-			subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
-			parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
-		}
+		if subnetErr != nil {
+			// Try the next candidate block when using a block query
+			if len(blockInfos) > 1 {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find a free /%d prefix in block: %s (%s)\n", d.Get("prefix_size").(int), blockInfo["name"], subnetErr))
+				continue
+			}
 
-		// Specify if subnet is terminal
-		if d.Get("terminal").(bool) {
-			parameters.Add("is_terminal", "1")
-		} else {
-			parameters.Add("is_terminal", "0")
+			// Reporting a failure
+			return diag.FromErr(subnetErr)
 		}
 
-		// Building class_parameters
-		classParameters := url.Values{}
-
-		// Generate class parameter for the gateway if required
-		goffset := d.Get("gateway_offset").(int)
+		for i := 0; i < len(subnetAddresses); i++ {
+			// Building parameters
+			parameters := url.Values{}
+			parameters.Add("site_id", siteID)
+			parameters.Add("add_flag", "new_only")
+			parameters.Add("subnet_name", d.Get("name").(string))
+			parameters.Add("subnet_addr", hexiptoip(subnetAddresses[i]))
+			parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+			parameters.Add("subnet_class_name", d.Get("class").(string))
+
+			// Associate the subnet to a VLAN if required
+			if d.Get("vlan_domain").(string) != "" {
+				urlfromsubnetvlan(&parameters, d)
+			}
 
-		if goffset != 0 {
-			if goffset > 0 {
-				gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(goffset))
+			// If no block specified, create an IP block
+			if blockInfo["id"].(string) == "" {
+				parameters.Add("subnet_level", "0")
 			} else {
-				gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(prefixlengthtosize(d.Get("prefix_size").(int))) - uint32(abs(goffset)) - 1)
+				subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
+				parameters.Add("subnet_level", strconv.Itoa(subnetLevel+1))
 			}
 
-			classParameters.Add("gateway", gateway)
-			tflog.Debug(ctx, fmt.Sprintf("Subnet computed gateway: %s\n", gateway))
-		}
-
-		for k, v := range d.Get("class_parameters").(map[string]interface{}) {
-			classParameters.Add(k, v.(string))
-		}
+			// Specify if subnet is terminal
+			if d.Get("terminal").(bool) {
+				parameters.Add("is_terminal", "1")
+			} else {
+				parameters.Add("is_terminal", "0")
+			}
 
-		parameters.Add("subnet_class_parameters", classParameters.Encode())
+			// Building class_parameters
+			classParameters := url.Values{}
 
-		// Random Delay
-		time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+			// Generate class parameter for the gateway if required
+			goffset := d.Get("gateway_offset").(int)
 
-		// Sending the creation request
-		resp, body, err := s.Request("post", "rest/ip_subnet_add", &parameters)
+			if goffset != 0 {
+				if goffset > 0 {
+					gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(goffset))
+				} else {
+					gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(prefixlengthtosize(d.Get("prefix_size").(int))) - uint32(abs(goffset)) - 1)
+				}
 
-		if err == nil {
-			var buf [](map[string]interface{})
-			json.Unmarshal([]byte(body), &buf)
+				classParameters.Add("gateway", gateway)
+				tflog.Debug(ctx, fmt.Sprintf("Subnet computed gateway: %s\n", gateway))
+			}
 
-			prefix := hexiptoip(subnetAddresses[i]) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+			for k, v := range d.Get("class_parameters").(map[string]interface{}) {
+				classParameters.Add(k, v.(string))
+			}
 
-			// Checking the answer
-			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
-				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
-					tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
-					d.SetId(oid)
-					d.Set("prefix", prefix)
-					d.Set("address", hexiptoip(subnetAddresses[i]))
-					d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
-					if goffset != 0 {
-						d.Set("gateway", gateway)
+			parameters.Add("subnet_class_parameters", classParameters.Encode())
+			parameters.Add("subnet_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
+
+			// Random Delay
+			time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+
+			// Sending the creation request
+			resp, body, err := s.Request("post", "rest/ip_subnet_add", &parameters)
+
+			if err == nil {
+				var buf [](map[string]interface{})
+				json.Unmarshal([]byte(body), &buf)
+
+				prefix := hexiptoip(subnetAddresses[i]) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+
+				// Checking the answer
+				if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+					if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+						tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+						d.SetId(oid)
+						d.Set("selected_block", blockInfo["name"])
+						d.Set("prefix", prefix)
+						d.Set("address", hexiptoip(subnetAddresses[i]))
+						d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+						if goffset != 0 {
+							d.Set("gateway", gateway)
+						}
+
+						// Registering the reserved addresses along with the subnet
+						if err := subnetreservedapply(d, false, meta); err != nil {
+							return diag.FromErr(err)
+						}
+
+						// Registering the gateway's name, class and DNS records along with the subnet
+						if goffset != 0 {
+							if subnetgatewaymanaged(d) {
+								if err := subnetgatewayapply(d, false, meta); err != nil {
+									return diag.FromErr(err)
+								}
+							}
+
+							if err := subnetgatewaydnsapply(d, false, meta); err != nil {
+								return diag.FromErr(err)
+							}
+						}
+
+						return nil
 					}
-					return nil
-				}
-			} else {
-				if len(buf) > 0 {
-					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s (%s)\n", d.Get("name").(string), prefix, errMsg))
+				} else {
+					if len(buf) > 0 {
+						if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+							tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s (%s)\n", d.Get("name").(string), prefix, errMsg))
+						} else {
+							tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", d.Get("name").(string), prefix))
+						}
 					} else {
 						tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", d.Get("name").(string), prefix))
 					}
-				} else {
-					tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IP subnet: %s with prefix: %s\n", d.Get("name").(string), prefix))
 				}
+			} else {
+				// Reporting a failure
+				return diag.FromErr(err)
 			}
-		} else {
-			// Reporting a failure
-			return diag.FromErr(err)
 		}
 	}
 
@@ -266,27 +614,114 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	parameters.Add("subnet_name", d.Get("name").(string))
 	parameters.Add("subnet_class_name", d.Get("class").(string))
 
+	// Update the VLAN association if required
+	if d.HasChanges("vlan_domain", "vlan_id", "vlan") {
+		urlfromsubnetvlan(&parameters, d)
+	}
+
 	if d.Get("terminal").(bool) {
 		parameters.Add("is_terminal", "1")
 	} else {
 		parameters.Add("is_terminal", "0")
 	}
 
-	// Building class_parameters
-	classParameters := url.Values{}
+	// The reserved addresses no longer expected are released once the subnet is known to fit, they may stand beyond its new end
+	releasing := []map[string]interface{}{}
 
-	// Generate class parameter for the gateway if required
+	if d.HasChanges("reserved_addresses", "prefix_size") {
+		releasing = subnetreservedreleasing(d, false, false)
+	}
+
+	// The gateway is computed again as it may move along with its offset or the end of the subnet
+	oldGateway, _ := d.GetChange("gateway")
 	goffset := d.Get("gateway_offset").(int)
 
+	if d.HasChanges("gateway_offset", "prefix_size") {
+		if goffset != 0 {
+			d.Set("gateway", subnetaddressatoffset(d.Get("address").(string), d.Get("prefix_size").(int), goffset, false))
+		} else {
+			d.Set("gateway", "")
+		}
+	}
+
+	gatewayMoved := oldGateway.(string) != d.Get("gateway").(string)
+
+	// Resizing the IP subnet in place, its network address remains unchanged
+	// The previous gateway and the reserved addresses released along the way don't prevent the subnet from shrinking
+	if d.HasChange("prefix_size") {
+		ignored := []string{}
+
+		if gatewayMoved && oldGateway.(string) != "" {
+			ignored = append(ignored, oldGateway.(string))
+		}
+
+		for _, r := range releasing {
+			ignored = append(ignored, r["address"].(string))
+		}
+
+		if err := ipsubnetresizecheck(d.Id(), d.Get("address").(string), d.Get("prefix_size").(int), ignored, meta); err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		parameters.Add("subnet_addr", d.Get("address").(string))
+		parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+	}
+
+	released, err := subnetreservedrelease(d, releasing, false, meta)
+	var releasedGateway map[string]interface{}
+
+	// Registering again the released reserved addresses and gateway when the subnet can't be updated
+	rollback := func(err error) diag.Diagnostics {
+		if restoreErr := subnetreservedrestore(d, released, false, meta); restoreErr != nil {
+			return diag.Errorf("%s, %s", err, restoreErr)
+		}
+
+		if restoreErr := subnetgatewayrestore(d.Get("space").(string), oldGateway.(string), releasedGateway, false, meta); restoreErr != nil {
+			return diag.Errorf("%s, %s", err, restoreErr)
+		}
+
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if err != nil {
+		return rollback(err)
+	}
+
+	gatewayDNSChanged := gatewayMoved || d.HasChanges("gateway_name", "gateway_dns_server", "gateway_dns_view", "gateway_dns_forward", "gateway_dns_reverse")
+
+	// Releasing the IP object of the previous gateway, it may stand beyond the new end of the subnet
+	if gatewayMoved && oldGateway.(string) != "" {
+		entry, err := subnetgatewayentry(d.Get("space").(string), oldGateway.(string), false, meta)
+
+		if err != nil {
+			return rollback(err)
+		}
+
+		if err := subnetgatewayrelease(d.Get("space").(string), oldGateway.(string), false, meta); err != nil {
+			return rollback(err)
+		}
+
+		releasedGateway = entry
+	}
+
+	// Building class_parameters
+	classParameters := url.Values{}
+
+	// Generate class parameter for the gateway if required, or drop it along with the gateway
 	if goffset != 0 {
 		classParameters.Add("gateway", d.Get("gateway").(string))
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
+	} else if gatewayMoved {
+		classParameters.Add("gateway", "")
 	}
 
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
 	parameters.Add("subnet_class_parameters", classParameters.Encode())
+	parameters.Add("subnet_class_parameters_properties", urlfromclassparamsproperties(d.Get("class_parameters_inheritance"), d.Get("class_parameters_propagation")).Encode())
 
 	// Sending the update request
 	resp, body, err := s.Request("put", "rest/ip_subnet_add", &parameters)
@@ -300,6 +735,38 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP subnet (oid): %s\n", oid))
 				d.SetId(oid)
+				d.Set("prefix", d.Get("address").(string)+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+				d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+
+				// Registering the expected reserved addresses
+				if d.HasChanges("reserved_addresses", "prefix_size") {
+					if err := subnetreservedapply(d, false, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
+				// Releasing the DNS records of the previous gateway
+				if gatewayDNSChanged {
+					if err := subnetgatewaydnsrelease(d, false, meta); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+
+				// Registering the gateway's name, class and DNS records
+				if goffset != 0 {
+					if (gatewayMoved && subnetgatewaymanaged(d)) || d.HasChanges("gateway_name", "gateway_class", "gateway_class_parameters") {
+						if err := subnetgatewayapply(d, false, meta); err != nil {
+							return diag.FromErr(err)
+						}
+					}
+
+					if gatewayDNSChanged {
+						if err := subnetgatewaydnsapply(d, false, meta); err != nil {
+							return diag.FromErr(err)
+						}
+					}
+				}
+
 				return nil
 			}
 		}
@@ -307,21 +774,21 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		// Reporting a failure
 		if len(buf) > 0 {
 			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-				return diag.Errorf("Unable to update IP subnet: %s (%s)", d.Get("name").(string), errMsg)
+				return rollback(fmt.Errorf("Unable to update IP subnet: %s (%s)", d.Get("name").(string), errMsg))
 			}
 		}
 
-		return diag.Errorf("Unable to update IP subnet: %s\n", d.Get("name").(string))
+		return rollback(fmt.Errorf("Unable to update IP subnet: %s\n", d.Get("name").(string)))
 	}
 
 	// Reporting a failure
-	return diag.FromErr(err)
+	return rollback(err)
 }
 
 func resourceipsubnetgatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	if d.Get("gateway") != nil {
+	if d.Get("gateway").(string) != "" {
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_name", d.Get("space").(string))
@@ -339,11 +806,11 @@ func resourceipsubnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 				// Reporting a failure
 				if len(buf) > 0 {
 					if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
-						tflog.Debug(ctx, fmt.Sprintf("Unable to delete IP subnet's gateway: %s (%s)", d.Get("gateway").(string), errMsg))
+						return diag.Errorf("Unable to delete IP subnet's gateway: %s (%s)", d.Get("gateway").(string), errMsg)
 					}
 				}
 
-				tflog.Debug(ctx, fmt.Sprintf("Unable to delete IP subnet's gateway: %s", d.Get("gateway").(string)))
+				return diag.Errorf("Unable to delete IP subnet's gateway: %s", d.Get("gateway").(string))
 			}
 
 			// Log deletion
@@ -364,9 +831,40 @@ func resourceipsubnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 func resourceipsubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
-	// Delete related resources such as the Gateway
+	// Refusing to delete a protected or non empty IP subnet, ignoring its own gateway and reserved addresses
+	addressWhereClause := "subnet_id='" + d.Id() + "' AND type='ip'"
+
+	if d.Get("gateway_offset").(int) != 0 && d.Get("gateway").(string) != "" {
+		addressWhereClause += " AND ip_addr!='" + iptohexip(d.Get("gateway").(string)) + "'"
+	}
+
+	for _, r := range d.Get("reserved_addresses").([]interface{}) {
+		if address, _ := r.(map[string]interface{})["address"].(string); address != "" {
+			addressWhereClause += " AND ip_addr!='" + iptohexip(address) + "'"
+		}
+	}
+
+	if err := deletionprotectioncheck(d, "IP subnet", map[string]string{
+		"ip_block_subnet_count": "parent_subnet_id='" + d.Id() + "'",
+		"ip_pool_count":         "subnet_id='" + d.Id() + "'",
+		"ip_address_count":      addressWhereClause,
+	}, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Delete related resources such as the reserved addresses and the Gateway
+	if _, err := subnetreservedrelease(d, subnetreservedreleasing(d, true, false), false, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.Get("gateway_offset") != 0 {
-		resourceipsubnetgatewayDelete(ctx, d, meta)
+		if err := subnetgatewaydnsrelease(d, false, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if diags := resourceipsubnetgatewayDelete(ctx, d, meta); diags.HasError() {
+			return diags
+		}
 	}
 
 	// Building parameters
@@ -423,9 +921,15 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("block", buf[0]["parent_subnet_name"].(string))
+			d.Set("selected_block", buf[0]["parent_subnet_name"].(string))
+
+			if len(d.Get("block_query").(string)) == 0 {
+				ipreferenceset(d, "block", buf[0]["parent_subnet_name"].(string))
+			}
+
 			d.Set("name", buf[0]["subnet_name"].(string))
 			d.Set("class", buf[0]["subnet_class_name"].(string))
+			subnetvlanset(d, buf[0], false)
 
 			if buf[0]["is_terminal"].(string) == "1" {
 				d.Set("terminal", true)
@@ -451,6 +955,24 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["subnet_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
+
+			// Updating local reserved addresses
+			if err := subnetreservedread(d, d.Id(), false, meta); err != nil {
+				return diag.FromErr(err)
+			}
+
+			// Updating local gateway's name, class and class parameters
+			if err := subnetgatewayread(d, false, meta); err != nil {
+				return diag.FromErr(err)
+			}
 
 			return nil
 		}
@@ -492,7 +1014,12 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
-			d.Set("block", buf[0]["parent_subnet_name"].(string))
+			d.Set("selected_block", buf[0]["parent_subnet_name"].(string))
+
+			if len(d.Get("block_query").(string)) == 0 {
+				d.Set("block", buf[0]["parent_subnet_name"].(string))
+			}
+
 			d.Set("name", buf[0]["subnet_name"].(string))
 			d.Set("request_ip", "")
 
@@ -508,6 +1035,7 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 			d.Set("gateway_offset", 0)
 
 			d.Set("class", buf[0]["subnet_class_name"].(string))
+			subnetvlanset(d, buf[0], false)
 
 			// Setting local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
@@ -527,6 +1055,14 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 			}
 
 			d.Set("class_parameters", computedClassParameters)
+			d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
+
+			// Updating local class_parameters properties
+			if properties, propertiesExist := buf[0]["subnet_class_parameters_properties"].(string); propertiesExist {
+				retrievedClassParametersProperties, _ := url.ParseQuery(properties)
+				d.Set("class_parameters_inheritance", classparamsproperties(d.Get("class_parameters_inheritance").(map[string]interface{}), retrievedClassParametersProperties, classParametersInheritanceProperties))
+				d.Set("class_parameters_propagation", classparamsproperties(d.Get("class_parameters_propagation").(map[string]interface{}), retrievedClassParametersProperties, classParametersPropagationProperties))
+			}
 
 			return []*schema.ResourceData{d}, nil
 		}
@@ -0,0 +1,225 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+func resourceipsubnetmerge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipsubnetmergeCreate,
+		ReadContext:   resourceipsubnetmergeRead,
+		DeleteContext: resourceipsubnetmergeDelete,
+
+		Description: heredoc.Doc(`
+			IP subnet merge allows to merge several adjacent terminal IP subnets into a single one.
+			The first subnet is grown in place and keeps its IP addresses and pools, the other subnets must be empty.
+			Destroying this resource keeps the resulting subnet untouched.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnets to merge.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The names of the adjacent IP subnets to merge.",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    2,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the resulting IP subnet (Default: the name of the first subnet).",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The prefix of the resulting IP subnet.",
+				Computed:    true,
+			},
+			"prefix_size": {
+				Type:        schema.TypeInt,
+				Description: "The prefix length of the resulting IP subnet.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceipsubnetmergeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subnetInfos := []map[string]interface{}{}
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	for _, subnetName := range d.Get("subnets").([]interface{}) {
+		subnetInfo, subnetErr := ipsubnetinfobyname(siteID, subnetName.(string), true, meta)
+
+		if subnetInfo == nil || subnetErr != nil {
+			return diag.Errorf("Unable to merge IP subnets, unable to find IP subnet: %s\n", subnetName.(string))
+		}
+
+		subnetInfos = append(subnetInfos, subnetInfo)
+	}
+
+	sort.Slice(subnetInfos, func(i, j int) bool {
+		return iptolong(subnetInfos[i]["start_addr"].(string)) < iptolong(subnetInfos[j]["start_addr"].(string))
+	})
+
+	// Ensure the subnets are adjacent siblings making up a valid prefix once merged
+	start := iptolong(subnetInfos[0]["start_addr"].(string))
+	size := 0
+
+	for i, subnetInfo := range subnetInfos {
+		if i > 0 {
+			if iptolong(subnetInfo["start_addr"].(string)) != iptolong(subnetInfos[i-1]["end_addr"].(string))+1 {
+				return diag.Errorf("Unable to merge IP subnets: %s and %s are not adjacent\n", subnetInfos[i-1]["name"].(string), subnetInfo["name"].(string))
+			}
+
+			if subnetInfo["parent_id"] != subnetInfos[0]["parent_id"] {
+				return diag.Errorf("Unable to merge IP subnets: %s and %s don't belong to the same block\n", subnetInfos[0]["name"].(string), subnetInfo["name"].(string))
+			}
+		}
+
+		size += subnetInfo["size"].(int)
+	}
+
+	prefixSize := sizetoprefixlength(size)
+
+	if prefixlengthtosize(prefixSize) != size || start%uint32(size) != 0 {
+		return diag.Errorf("Unable to merge IP subnets, %s - %s is not a valid prefix\n", subnetInfos[0]["start_addr"].(string), subnetInfos[len(subnetInfos)-1]["end_addr"].(string))
+	}
+
+	name := d.Get("name").(string)
+
+	if name == "" {
+		name = subnetInfos[0]["name"].(string)
+	}
+
+	// The merged subnets must be empty, checking them before changing anything
+	for _, subnetInfo := range subnetInfos[1:] {
+		for _, check := range []struct {
+			service string
+			kind    string
+		}{
+			{"ip_address_count", "IP address(es)"},
+			{"ip_pool_count", "pool(s)"},
+		} {
+			count, err := objectcount(check.service, "subnet_id='"+subnetInfo["id"].(string)+"'", meta)
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if count > 0 {
+				return diag.Errorf("Unable to merge IP subnets, %s still holds %d %s\n", subnetInfo["name"].(string), count, check.kind)
+			}
+		}
+	}
+
+	// The first subnet is grown in place over the other ones
+	if err := ipsubnetresize(subnetInfos[0]["id"].(string), name, subnetInfos[0]["start_addr"].(string), prefixSize, meta); err != nil {
+		return diag.Errorf("Unable to merge IP subnets (%s)\n", err)
+	}
+
+	// The merged subnets left behind are removed
+	for _, subnetInfo := range subnetInfos[1:] {
+		count, err := objectcount("ip_block_subnet_count", "subnet_id='"+subnetInfo["id"].(string)+"'", meta)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		parameters := url.Values{}
+		parameters.Add("subnet_id", subnetInfo["id"].(string))
+
+		if err := objectdelete("rest/ip_subnet_delete", &parameters, meta); err != nil {
+			return diag.Errorf("Unable to merge IP subnets, unable to delete empty IP subnet: %s (%s)\n", subnetInfo["name"].(string), err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleted empty IP subnet (oid): %s\n", subnetInfo["id"].(string)))
+	}
+
+	d.SetId(subnetInfos[0]["id"].(string))
+	d.Set("prefix", subnetInfos[0]["start_addr"].(string)+"/"+strconv.Itoa(prefixSize))
+	d.Set("prefix_size", prefixSize)
+
+	tflog.Debug(ctx, fmt.Sprintf("Merged IP subnets into (oid): %s\n", d.Id()))
+
+	return nil
+}
+
+func resourceipsubnetmergeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The resulting subnet is kept, only the local state is removed
+	tflog.Debug(ctx, fmt.Sprintf("Forgetting IP subnet merge (oid): %s, resulting subnet is kept\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipsubnetmergeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			subnetSize, _ := strconv.Atoi(buf[0]["subnet_size"].(string))
+			prefixSize := sizetoprefixlength(subnetSize)
+
+			d.Set("prefix", hexiptoip(buf[0]["start_ip_addr"].(string))+"/"+strconv.Itoa(prefixSize))
+			d.Set("prefix_size", prefixSize)
+
+			return nil
+		}
+
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IP subnet (oid): %s\n", d.Id()))
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IP subnet resulting from the merge (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
@@ -0,0 +1,253 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func resourceipsubnetsplit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipsubnetsplitCreate,
+		ReadContext:   resourceipsubnetsplitRead,
+		DeleteContext: resourceipsubnetsplitDelete,
+
+		Description: heredoc.Doc(`
+			IP subnet split allows to split an existing terminal IP subnet into several smaller subnets of the same size.
+			The original subnet is shrunk in place to become the first resulting subnet, its IP addresses and pools must all stand within it.
+			Destroying this resource keeps the resulting subnets untouched.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnet to split.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP subnet to split.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the resulting IP subnets (ex: 26 to split a '/24' into four '/26').",
+				ValidateFunc: validation.IntBetween(1, 31),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name template of the resulting IP subnets, '%d' is replaced by the position of the subnet (starting at 1).",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The prefixes of the resulting IP subnets.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"subnet_ids": {
+				Type:        schema.TypeList,
+				Description: "The oids of the resulting IP subnets.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Register a new terminal IP subnet at the given level
+// Return the oid of the created subnet
+func ipsubnetadd(siteID string, subnetName string, address string, prefixLength int, level string, meta interface{}) (string, error) {
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("subnet_name", subnetName)
+	parameters.Add("subnet_addr", address)
+	parameters.Add("subnet_prefix", strconv.Itoa(prefixLength))
+	parameters.Add("subnet_level", level)
+	parameters.Add("is_terminal", "1")
+
+	oid, err := objectadd("rest/ip_subnet_add", &parameters, meta)
+
+	if err != nil {
+		return "", fmt.Errorf("SOLIDServer - Unable to create IP subnet: %s with prefix: %s/%d (%s)\n", subnetName, address, prefixLength, err)
+	}
+
+	return oid, nil
+}
+
+func resourceipsubnetsplitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetInfo == nil || subnetErr != nil {
+		// Reporting a failure
+		if subnetInfo == nil {
+			return diag.Errorf("Unable to split IP subnet: %s, unable to find it\n", d.Get("subnet").(string))
+		}
+
+		return diag.FromErr(subnetErr)
+	}
+
+	prefixSize := d.Get("prefix_size").(int)
+
+	if prefixSize <= subnetInfo["prefix_length"].(int) {
+		return diag.Errorf("Unable to split IP subnet: %s (/%d) into /%d subnets, the prefix must be longer\n", d.Get("subnet").(string), subnetInfo["prefix_length"].(int), prefixSize)
+	}
+
+	start := iptolong(subnetInfo["start_addr"].(string))
+	newSize := uint32(prefixlengthtosize(prefixSize))
+	count := uint32(subnetInfo["size"].(int)) / newSize
+
+	addresses, listErr := ipaddressrangelist(subnetInfo["id"].(string), subnetInfo["start_hex_addr"].(string), subnetInfo["end_hex_addr"].(string), meta)
+
+	if listErr != nil {
+		// Reporting a failure
+		return diag.FromErr(listErr)
+	}
+
+	// Addresses can't become the network or broadcast address of a resulting subnet
+	for _, addr := range addresses {
+		offset := iptolong(hexiptoip(addr["ip_addr"].(string))) - start
+
+		if offset%newSize == 0 || offset%newSize == newSize-1 {
+			return diag.Errorf("Unable to split IP subnet: %s, IP address %s would become the network or broadcast address of a /%d subnet\n", d.Get("subnet").(string), hexiptoip(addr["ip_addr"].(string)), prefixSize)
+		}
+	}
+
+	// Pools can't span several resulting subnets
+	pools, poolErr := objectlistbyquery("rest/ip_pool_list", "subnet_id='"+subnetInfo["id"].(string)+"'", "", "", 0, meta)
+
+	if poolErr != nil {
+		// Reporting a failure
+		return diag.FromErr(poolErr)
+	}
+
+	for _, pool := range pools {
+		if (iptolong(hexiptoip(entrystring(pool, "start_ip_addr")))-start)/newSize != (iptolong(hexiptoip(entrystring(pool, "end_ip_addr")))-start)/newSize {
+			return diag.Errorf("Unable to split IP subnet: %s, IP pool %s would span several /%d subnets\n", d.Get("subnet").(string), entrystring(pool, "pool_name"), prefixSize)
+		}
+	}
+
+	// The original subnet is shrunk in place to become the first resulting subnet, no object may stand beyond it
+	if err := ipsubnetresizecheck(subnetInfo["id"].(string), longtoip(start), prefixSize, []string{}, meta); err != nil {
+		return diag.Errorf("Unable to split IP subnet: %s (%s)\n", d.Get("subnet").(string), err)
+	}
+
+	if err := ipsubnetresize(subnetInfo["id"].(string), ipaddressrangename(d.Get("name").(string), 1), longtoip(start), prefixSize, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subnetInfo["id"].(string))
+
+	subnets := []string{longtoip(start) + "/" + strconv.Itoa(prefixSize)}
+	subnetIDs := []string{subnetInfo["id"].(string)}
+
+	for i := uint32(1); i < count; i++ {
+		address := longtoip(start + i*newSize)
+
+		oid, err := ipsubnetadd(siteID, ipaddressrangename(d.Get("name").(string), int(i)+1), address, prefixSize, subnetInfo["level"].(string), meta)
+
+		if err != nil {
+			// Nothing was created yet, the original subnet can recover its size
+			if i == 1 {
+				if resizeErr := ipsubnetresize(subnetInfo["id"].(string), subnetInfo["name"].(string), longtoip(start), subnetInfo["prefix_length"].(int), meta); resizeErr == nil {
+					d.SetId("")
+					return diag.FromErr(err)
+				}
+			}
+
+			d.Set("subnets", subnets)
+			d.Set("subnet_ids", subnetIDs)
+
+			return diag.Errorf("Unable to split IP subnet: %s (%s), %s - %s is left without subnet\n", d.Get("subnet").(string), err, address, subnetInfo["end_addr"].(string))
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+
+		subnets = append(subnets, address+"/"+strconv.Itoa(prefixSize))
+		subnetIDs = append(subnetIDs, oid)
+	}
+
+	d.Set("subnets", subnets)
+	d.Set("subnet_ids", subnetIDs)
+
+	tflog.Debug(ctx, fmt.Sprintf("Split IP subnet: %s into: %s\n", d.Get("subnet").(string), strings.Join(subnets, ", ")))
+
+	return nil
+}
+
+func resourceipsubnetsplitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The resulting subnets are kept, only the local state is removed
+	tflog.Debug(ctx, fmt.Sprintf("Forgetting IP subnet split (oid): %s, resulting subnets are kept\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipsubnetsplitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	subnets := []string{}
+
+	for _, subnetID := range d.Get("subnet_ids").([]interface{}) {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("subnet_id", subnetID.(string))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", "rest/ip_block_subnet_info", &parameters)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 || len(buf) == 0 {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP subnet (oid): %s\n", subnetID.(string)))
+
+			// Reporting a failure
+			return diag.Errorf("Unable to find IP subnet (oid): %s resulting from the split of: %s\n", subnetID.(string), d.Get("subnet").(string))
+		}
+
+		subnetSize, _ := strconv.Atoi(buf[0]["subnet_size"].(string))
+		subnets = append(subnets, hexiptoip(buf[0]["start_ip_addr"].(string))+"/"+strconv.Itoa(sizetoprefixlength(subnetSize)))
+	}
+
+	d.Set("subnets", subnets)
+
+	return nil
+}
@@ -61,6 +61,23 @@ func resourcevlan() *schema.Resource {
 				ForceNew:    false,
 				Default:     map[string]string{},
 			},
+			"class_parameters_all": {
+				Type:        schema.TypeMap,
+				Description: "All the class parameters associated to vlan, as reported by SOLIDserver (including the ones not managed by terraform).",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignore_class_parameters": {
+				Type:        schema.TypeList,
+				Description: "The class parameters to exclude from class_parameters_all.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -264,6 +281,7 @@ func resourcevlanRead(ctx context.Context, d *schema.ResourceData, meta interfac
 				}
 
 				d.Set("class_parameters", computedClassParameters)
+				d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			}
 
 			return nil
@@ -329,6 +347,7 @@ func resourcevlanImportState(ctx context.Context, d *schema.ResourceData, meta i
 				}
 
 				d.Set("class_parameters", computedClassParameters)
+				d.Set("class_parameters_all", classparametersall(retrievedClassParameters, d.Get("ignore_class_parameters").([]interface{})))
 			}
 
 			return []*schema.ResourceData{d}, nil
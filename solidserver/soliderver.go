@@ -55,9 +55,10 @@ type SOLIDserver struct {
 	AdditionalTrustCertsFile string
 	Version                  int
 	Authenticated            bool
+	ProtectNonEmpty          bool
 }
 
-func NewSOLIDserver(ctx context.Context, host string, username string, password string, sslverify bool, certsfile string, version string) (*SOLIDserver, diag.Diagnostics) {
+func NewSOLIDserver(ctx context.Context, host string, username string, password string, sslverify bool, certsfile string, version string, protectnonempty bool) (*SOLIDserver, diag.Diagnostics) {
 	s := &SOLIDserver{
 		Ctx:                      ctx,
 		Host:                     host,
@@ -68,6 +69,7 @@ func NewSOLIDserver(ctx context.Context, host string, username string, password
 		AdditionalTrustCertsFile: certsfile,
 		Version:                  0,
 		Authenticated:            false,
+		ProtectNonEmpty:          protectnonempty,
 	}
 
 	if err := s.GetVersion(version); err != nil {
@@ -1,13 +1,16 @@
 package solidserver
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"inet.af/netaddr"
 	"math/big"
+	"net"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -333,6 +336,17 @@ func prefixlengthtosize(length int) int {
 	return -1
 }
 
+// Check whether an IP address is the network address of a prefix of the given length
+func ipisnetworkaddress(ip string, length int) bool {
+	size := prefixlengthtosize(length)
+
+	if size <= 0 {
+		return false
+	}
+
+	return uint64(iptolong(ip))%uint64(size) == 0
+}
+
 // Compute the netmask of a CIDR prefix from its length
 // Return an empty string in case of failure
 func prefixlengthtohexip(length int) string {
@@ -368,6 +382,135 @@ func urlfromclassparams(parameters interface{}) url.Values {
 	return classParameters
 }
 
+// Build the map of all the class parameters retrieved from SOLIDserver
+// Excluding the keys listed within the ignore list
+func classparametersall(retrievedClassParameters url.Values, ignoreClassParameters []interface{}) map[string]string {
+	computedClassParameters := map[string]string{}
+
+	for ck, cv := range retrievedClassParameters {
+		if len(cv) > 0 && stringOffsetInSlice(ck, toStringArray(ignoreClassParameters)) == -1 {
+			computedClassParameters[ck] = cv[0]
+		}
+	}
+
+	return computedClassParameters
+}
+
+// Supported class parameters inheritance and propagation properties
+var classParametersInheritanceProperties = []string{"inherit", "set"}
+var classParametersPropagationProperties = []string{"propagate", "restrict"}
+
+// Validate class parameters inheritance properties against the supported values
+func resourceclassparamsinheritancevalidate(v interface{}, _ string) ([]string, []error) {
+	for ck, cv := range v.(map[string]interface{}) {
+		if stringOffsetInSlice(cv.(string), classParametersInheritanceProperties) == -1 {
+			return nil, []error{fmt.Errorf("Unsupported inheritance property for class parameter %s (Supported: %s).\n", ck, strings.Join(classParametersInheritanceProperties, ", "))}
+		}
+	}
+
+	return nil, nil
+}
+
+// Validate class parameters propagation properties against the supported values
+func resourceclassparamspropagationvalidate(v interface{}, _ string) ([]string, []error) {
+	for ck, cv := range v.(map[string]interface{}) {
+		if stringOffsetInSlice(cv.(string), classParametersPropagationProperties) == -1 {
+			return nil, []error{fmt.Errorf("Unsupported propagation property for class parameter %s (Supported: %s).\n", ck, strings.Join(classParametersPropagationProperties, ", "))}
+		}
+	}
+
+	return nil, nil
+}
+
+// Build url value object from class parameters inheritance and/or propagation properties
+// The properties of a same class parameter are comma-joined (ex: inherit,propagate)
+// Return an url.Values{} object
+func urlfromclassparamsproperties(properties ...interface{}) url.Values {
+	classParametersProperties := url.Values{}
+
+	for _, p := range properties {
+		for k, v := range p.(map[string]interface{}) {
+			if current := classParametersProperties.Get(k); current != "" {
+				classParametersProperties.Set(k, current+","+v.(string))
+			} else {
+				classParametersProperties.Set(k, v.(string))
+			}
+		}
+	}
+
+	return classParametersProperties
+}
+
+// Build the map of class parameters properties for the keys currently managed
+// Only the retrieved properties within the supported list are kept
+// The keys not reported by SOLIDserver keep their current property
+func classparamsproperties(currentProperties map[string]interface{}, retrievedProperties url.Values, supported []string) map[string]string {
+	computedProperties := map[string]string{}
+
+	for ck, cv := range currentProperties {
+		computedProperties[ck], _ = cv.(string)
+
+		if rv, rvExist := retrievedProperties[ck]; rvExist {
+			for _, v := range rv {
+				for _, p := range strings.Split(v, ",") {
+					if stringOffsetInSlice(p, supported) != -1 {
+						computedProperties[ck] = p
+					}
+				}
+			}
+		}
+	}
+
+	return computedProperties
+}
+
+// Add the parameters associating a subnet to a VLAN
+// The VLAN is referenced by name when provided, otherwise by its VLAN ID
+func urlfromsubnetvlan(parameters *url.Values, d *schema.ResourceData) {
+	parameters.Add("vlmdomain_name", d.Get("vlan_domain").(string))
+
+	if d.Get("vlan").(string) != "" {
+		parameters.Add("vlmvlan_name", d.Get("vlan").(string))
+	} else {
+		parameters.Add("vlmvlan_vlan_id", strconv.Itoa(d.Get("vlan_id").(int)))
+	}
+}
+
+// Ensure a VLAN domain is only provided along with the VLAN to associate, by name or by ID
+// Otherwise the subnet would be associated to the VLAN 0 of the domain
+func subnetvlancheck(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("vlan_domain") || !d.NewValueKnown("vlan") || !d.NewValueKnown("vlan_id") {
+		return nil
+	}
+
+	if d.Get("vlan_domain").(string) != "" && d.Get("vlan").(string) == "" && d.Get("vlan_id").(int) == 0 {
+		return fmt.Errorf("vlan_domain: %s requires either vlan or vlan_id to be specified", d.Get("vlan_domain").(string))
+	}
+
+	return nil
+}
+
+// Update the VLAN association of a subnet from the retrieved subnet information
+// The VLAN name is only tracked when used to reference the VLAN
+func subnetvlanset(d *schema.ResourceData, subnet map[string]interface{}, dataSource bool) {
+	if vlanDomain, vlanDomainExist := subnet["vlmdomain_name"].(string); vlanDomainExist {
+		d.Set("vlan_domain", vlanDomain)
+	}
+
+	if dataSource || d.Get("vlan").(string) != "" {
+		if vlanName, vlanNameExist := subnet["vlmvlan_name"].(string); vlanNameExist {
+			d.Set("vlan", vlanName)
+		}
+	}
+
+	if dataSource || d.Get("vlan").(string) == "" {
+		if vlanID, vlanIDExist := subnet["vlmvlan_vlan_id"].(string); vlanIDExist {
+			vnid, _ := strconv.Atoi(vlanID)
+			d.Set("vlan_id", vnid)
+		}
+	}
+}
+
 // Return the oid of a device from hostdev_name
 // Or an empty string in case of failure
 func hostdevidbyname(hostdevName string, meta interface{}) (string, error) {
@@ -477,260 +620,484 @@ func ip6addressfindfree(subnetID string, poolID string, meta interface{}) ([]str
 	return []string{}, err
 }
 
-// Return an available vlan from specified vlmdomain_name
-// Or an empty table strings in case of failure
-func vlanidfindfree(vlmdomainName string, meta interface{}) ([]string, error) {
-	s := meta.(*SOLIDserver)
+// Return the SLAAC IPv6 address derived from a MAC address (modified EUI-64) within the given subnet
+// SLAAC requires a /64 subnet, the interface ID filling the lower 64 bits of the address
+func ip6eui64address(subnetInfo map[string]interface{}, mac string) (string, error) {
+	if subnetInfo["prefix_length"].(int) != 64 {
+		return "", fmt.Errorf("Unable to derive EUI-64 IPv6 address, subnet %s/%d is not a /64\n", hexip6toip6(subnetInfo["start_hex_addr"].(string)), subnetInfo["prefix_length"].(int))
+	}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("limit", "16")
+	hwAddr, hwErr := net.ParseMAC(mac)
 
-	if s.Version < 700 {
-		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND row_enabled='2'")
-	} else {
-		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND type='free'")
+	if hwErr != nil || len(hwAddr) != 6 {
+		return "", fmt.Errorf("Unable to derive EUI-64 IPv6 address, invalid MAC address: %s\n", mac)
 	}
 
-	// Sending the creation request
-	resp, body, err := s.Request("get", "rest/vlmvlan_list", &parameters)
+	// Flipping the universal/local bit and inserting FF:FE in the middle of the MAC address
+	interfaceID := fmt.Sprintf("%02x%02x%02xfffe%02x%02x%02x", hwAddr[0]^0x02, hwAddr[1], hwAddr[2], hwAddr[3], hwAddr[4], hwAddr[5])
+	hexAddr := subnetInfo["start_hex_addr"].(string)[0:16] + interfaceID
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	if strings.Compare(subnetInfo["start_hex_addr"].(string), hexAddr) != -1 ||
+		strings.Compare(hexAddr, subnetInfo["end_hex_addr"].(string)) != -1 {
+		return "", fmt.Errorf("Unable to derive EUI-64 IPv6 address, address %s is out of network's range (%s - %s)\n", hexip6toip6(hexAddr), hexip6toip6(subnetInfo["start_hex_addr"].(string)), hexip6toip6(subnetInfo["end_hex_addr"].(string)))
+	}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			vnIDs := []string{}
+	return hexip6toip6(hexAddr), nil
+}
 
-			for i := range buf {
-				if s.Version < 700 {
-					if vnID, vnIDExist := buf[i]["vlmvlan_vlan_id"].(string); vnIDExist {
-						tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %s\n", vnID))
-						vnIDs = append(vnIDs, vnID)
-					}
-				} else {
-					if startVlanID, startVlanIDExist := buf[i]["free_start_vlan_id"].(string); startVlanIDExist {
-						if endVlanID, endVlanIDExist := buf[i]["free_end_vlan_id"].(string); endVlanIDExist {
-							vnID, _ := strconv.Atoi(startVlanID)
-							maxVnID, _ := strconv.Atoi(endVlanID)
+// Convert an hexadecimal IP address string into a big integer
+func hexiptobigint(hexip string) *big.Int {
+	res, ok := new(big.Int).SetString(hexip, 16)
 
-							j := 0
-							for vnID < maxVnID && j < 8 {
-								tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %d\n", vnID))
-								vnIDs = append(vnIDs, strconv.Itoa(vnID))
-								vnID++
-								j++
-							}
-						}
-					}
-				}
-			}
-			return vnIDs, nil
-		}
+	if !ok {
+		return nil
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free vlan ID in vlan domain: %s\n", vlmdomainName))
+	return res
+}
 
-	return []string{}, err
+// Convert a big integer into an IP address string (IPv4 or IPv6)
+func bigintoip(value *big.Int, v6 bool) string {
+	if v6 {
+		return hexip6toip6(fmt.Sprintf("%032x", value))
+	}
+
+	return hexiptoip(fmt.Sprintf("%08x", value))
 }
 
-// Return the oid of a space from site_name
-// Or an empty string in case of failure
-func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
-	s := meta.(*SOLIDserver)
+// Convert an IP address string into a big integer (IPv4 or IPv6)
+// Return nil in case of failure
+func iptobigint(ip string, v6 bool) *big.Int {
+	if v6 {
+		return hexiptobigint(ip6tohexip6(shortip6tolongip6(ip)))
+	}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_name='"+strings.ToLower(siteName)+"'")
+	return hexiptobigint(iptohexip(ip))
+}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_site_list", &parameters)
+// Validate an allocation exclusion entry
+// Supported formats: an IP address, an IP address range (a-b),
+// an offset (n) or an offset range (n-m) from the start of the subnet
+func resourceipallocationexcludevalidate(v interface{}, _ string) ([]string, []error) {
+	entry := v.(string)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	for _, bound := range strings.SplitN(entry, "-", 2) {
+		if _, err := strconv.ParseUint(bound, 10, 64); err == nil {
+			continue
+		}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if siteID, siteIDExist := buf[0]["site_id"].(string); siteIDExist {
-				return siteID, nil
-			}
+		if _, err := netaddr.ParseIP(bound); err == nil {
+			continue
 		}
-	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP space: %s\n", siteName))
+		return nil, []error{fmt.Errorf("Unsupported exclusion: %s, expecting an IP address, an offset or a range of them (a-b)\n", entry)}
+	}
 
-	return "", err
+	return nil, nil
 }
 
-// Return the oid of a vlan domain from vlmdomain_name
-// Or an empty string in case of failure
-func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error) {
-	s := meta.(*SOLIDserver)
+// Parse the allocation exclusion entries into a list of [start, end] ranges
+// Offsets are computed from the provided subnet start address
+func ipallocationexcluderanges(exclude []string, subnetStart *big.Int, v6 bool) ([][2]*big.Int, error) {
+	ranges := [][2]*big.Int{}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"'")
+	for _, entry := range exclude {
+		var bounds [2]*big.Int
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/vlmdomain_name", &parameters)
+		parts := strings.SplitN(entry, "-", 2)
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+		for i, bound := range parts {
+			if offset, err := strconv.ParseUint(bound, 10, 64); err == nil {
+				bounds[i] = new(big.Int).Add(subnetStart, new(big.Int).SetUint64(offset))
+			} else {
+				bounds[i] = iptobigint(bound, v6)
+			}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if vlmdomainID, vlmdomainIDExist := buf[0]["vlmdomain_id"].(string); vlmdomainIDExist {
-				return vlmdomainID, nil
+			if bounds[i] == nil {
+				return nil, fmt.Errorf("Unsupported exclusion: %s\n", entry)
 			}
 		}
+
+		if len(parts) == 1 {
+			bounds[1] = bounds[0]
+		}
+
+		if bounds[0].Cmp(bounds[1]) == 1 {
+			bounds[0], bounds[1] = bounds[1], bounds[0]
+		}
+
+		ranges = append(ranges, bounds)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find vlan domain: %s\n", vlmdomainName))
+	return ranges, nil
+}
 
-	return "", err
+// Return the excluded range containing the given address or nil if not excluded
+func ipallocationexcluded(addr *big.Int, ranges [][2]*big.Int) *[2]*big.Int {
+	for i := range ranges {
+		if ranges[i][0].Cmp(addr) <= 0 && addr.Cmp(ranges[i][1]) <= 0 {
+			return &ranges[i]
+		}
+	}
+
+	return nil
 }
 
-// Return the oid of a subnet from site_id, subnet_name and is_terminal property
-// Or an empty string in case of failure
-func ipsubnetidbyname(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
+// Filter out the IP addresses already in use within the given subnet
+// Addresses are provided and returned as hexadecimal strings
+func ipaddressfilterused(subnetID string, hexAddresses []string, v6 bool, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters
-	parameters := url.Values{}
+	if len(hexAddresses) == 0 {
+		return hexAddresses, nil
+	}
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + strings.ToLower(subnetName) + "'"
+	service := "rest/ip_address_list"
+	whereClause := "subnet_id='" + subnetID + "' AND type='ip' AND ip_addr IN ('" + strings.Join(hexAddresses, "','") + "')"
+	addrKey := "ip_addr"
 
-	if terminal {
-		whereClause += "AND is_terminal='1'"
-	} else {
-		whereClause += "AND is_terminal='0'"
+	if v6 {
+		service = "rest/ip6_address6_list"
+		whereClause = "subnet6_id='" + subnetID + "' AND type='ip6' AND ip6_addr IN ('" + strings.Join(hexAddresses, "','") + "')"
+		addrKey = "ip6_addr"
 	}
 
+	// Building parameters
+	parameters := url.Values{}
 	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
-
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	resp, body, err := s.Request("get", service, &parameters)
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet_id"].(string); subnetIDExist {
-				return subnetID, nil
-			}
-		}
+	if err != nil {
+		return []string{}, err
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", subnetName))
-
-	return "", err
-}
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
 
-// Return the oid of a pool from site_id and pool_name
-// Or an empty string in case of failure
-func ippoolidbyname(siteID string, poolName string, subnetName string, meta interface{}) (string, error) {
-	s := meta.(*SOLIDserver)
+	// An empty answer means that none of the addresses is in use
+	if resp.StatusCode == 204 || len(buf) == 0 {
+		return hexAddresses, nil
+	}
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+strings.ToLower(poolName)+"' AND subnet_name='"+strings.ToLower(subnetName)+"'")
+	if resp.StatusCode != 200 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return []string{}, fmt.Errorf("SOLIDServer - Unable to list used IP addresses: %s\n", errMsg)
+		}
 
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_pool_list", &parameters)
+		return []string{}, fmt.Errorf("SOLIDServer - Unable to list used IP addresses\n")
+	}
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	used := map[string]bool{}
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if poolID, poolIDExist := buf[0]["pool_id"].(string); poolIDExist {
-				return poolID, nil
-			}
+	for i := 0; i < len(buf); i++ {
+		if addr, addrExist := buf[i][addrKey].(string); addrExist {
+			used[strings.ToLower(addr)] = true
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP pool: %s\n", poolName))
+	free := []string{}
 
-	return "", err
+	for _, addr := range hexAddresses {
+		if !used[addr] {
+			free = append(free, addr)
+		}
+	}
+
+	return free, nil
 }
 
-// Return the oid of a pool from site_id and pool_name
-// Or an empty string in case of failure
-func ippoolinfobyname(siteID string, poolName string, subnetName string, meta interface{}) (map[string]interface{}, error) {
-	res := make(map[string]interface{})
+// Return available IP addresses from the given subnet (and optional pool)
+// according to the allocation strategy (first, last, random or offset)
+// skipping the excluded addresses
+// The addresses are checked by batches until a free one is found or the whole range is covered,
+// the random strategy scans the range from a random address, wrapping around its end
+// Or an empty table of string in case of failure
+func ipaddressfindbystrategy(subnetInfo map[string]interface{}, poolInfo map[string]interface{}, strategy string, offset int, exclude []string, v6 bool, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
-	// Building parameters
-	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool_name='"+strings.ToLower(poolName)+"' AND subnet_name='"+strings.ToLower(subnetName)+"'")
-
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_pool_list", &parameters)
+	const batchSize = 32
 
-	if err == nil {
-		var buf [](map[string]interface{})
-		json.Unmarshal([]byte(body), &buf)
+	hexFormat := "%08x"
 
-		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if poolID, poolIDExist := buf[0]["pool_id"].(string); poolIDExist {
-				res["id"] = poolID
+	if v6 {
+		hexFormat = "%032x"
+	}
 
-				if poolName, poolNameExist := buf[0]["pool_name"].(string); poolNameExist {
-					res["name"] = poolName
-				}
+	subnetStart := hexiptobigint(subnetInfo["start_hex_addr"].(string))
+	subnetEnd := hexiptobigint(subnetInfo["end_hex_addr"].(string))
 
-				if poolSize, poolSizeExist := buf[0]["pool_size"].(string); poolSizeExist {
-					res["size"], _ = strconv.Atoi(poolSize)
-				}
+	if subnetStart == nil || subnetEnd == nil {
+		return []string{}, fmt.Errorf("SOLIDServer - Unable to compute the address range of subnet (oid): %s\n", subnetInfo["id"].(string))
+	}
 
-				if poolStartAddr, poolStartAddrExist := buf[0]["start_ip_addr"].(string); poolStartAddrExist {
-					res["start_hex_addr"] = poolStartAddr
-					res["start_addr"] = hexiptoip(poolStartAddr)
-				}
+	// Network and broadcast addresses are never allocated, IPv6 subnets have no broadcast address
+	first := new(big.Int).Add(subnetStart, big.NewInt(1))
+	last := new(big.Int).Set(subnetEnd)
 
-				if poolEndAddr, poolEndAddrExist := buf[0]["end_ip_addr"].(string); poolEndAddrExist {
-					res["end_hex_addr"] = poolEndAddr
-					res["end_addr"] = hexiptoip(poolEndAddr)
-				}
+	if !v6 {
+		last.Sub(last, big.NewInt(1))
+	}
 
-				return res, nil
-			}
+	if poolInfo != nil {
+		if poolStart := hexiptobigint(poolInfo["start_hex_addr"].(string)); poolStart != nil && poolStart.Cmp(first) == 1 {
+			first = poolStart
+		}
+		if poolEnd := hexiptobigint(poolInfo["end_hex_addr"].(string)); poolEnd != nil && poolEnd.Cmp(last) == -1 {
+			last = poolEnd
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP pool: %s\n", poolName))
+	if first.Cmp(last) == 1 {
+		return []string{}, fmt.Errorf("SOLIDServer - No allocatable address within subnet (oid): %s\n", subnetInfo["id"].(string))
+	}
 
-	return nil, err
-}
+	excludeRanges, excludeErr := ipallocationexcluderanges(exclude, subnetStart, v6)
 
-// Return a map of information about a subnet from site_id, subnet_name and is_terminal property
-// Or nil in case of failure
-func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
-	res := make(map[string]interface{})
+	if excludeErr != nil {
+		return []string{}, excludeErr
+	}
+
+	// Fixed offset from the start of the subnet
+	if strategy == "offset" {
+		candidate := new(big.Int).Add(subnetStart, big.NewInt(int64(offset)))
+
+		if candidate.Cmp(first) == -1 || candidate.Cmp(last) == 1 {
+			return []string{}, fmt.Errorf("SOLIDServer - Allocation offset %d is out of the allocatable range\n", offset)
+		}
+
+		if ipallocationexcluded(candidate, excludeRanges) != nil {
+			return []string{}, fmt.Errorf("SOLIDServer - Allocation offset %d is part of the excluded addresses\n", offset)
+		}
+
+		free, err := ipaddressfilterused(subnetInfo["id"].(string), []string{fmt.Sprintf(hexFormat, candidate)}, v6, meta)
+
+		if err != nil {
+			return []string{}, err
+		}
+
+		if len(free) == 0 {
+			return []string{}, fmt.Errorf("SOLIDServer - Address at allocation offset %d is already in use\n", offset)
+		}
+
+		return []string{bigintoip(candidate, v6)}, nil
+	}
+
+	rangeSize := new(big.Int).Add(new(big.Int).Sub(last, first), big.NewInt(1))
+	scanned := new(big.Int)
+	cursor := new(big.Int)
+	addresses := []string{}
+
+	switch strategy {
+	case "last":
+		cursor.Set(last)
+	case "random":
+		start, randErr := rand.Int(rand.Reader, rangeSize)
+
+		if randErr != nil {
+			return []string{}, randErr
+		}
+
+		cursor.Add(first, start)
+	default:
+		cursor.Set(first)
+	}
+
+	for len(addresses) == 0 {
+		candidates := []string{}
+
+		switch strategy {
+		case "random":
+			for len(candidates) < batchSize && scanned.Cmp(rangeSize) < 0 {
+				if cursor.Cmp(last) == 1 {
+					cursor.Set(first)
+				}
+
+				if r := ipallocationexcluded(cursor, excludeRanges); r != nil {
+					end := new(big.Int).Set(r[1])
+
+					if end.Cmp(last) == 1 {
+						end.Set(last)
+					}
+
+					scanned.Add(scanned, new(big.Int).Sub(end, cursor)).Add(scanned, big.NewInt(1))
+					cursor.Add(end, big.NewInt(1))
+					continue
+				}
+
+				candidates = append(candidates, fmt.Sprintf(hexFormat, cursor))
+				scanned.Add(scanned, big.NewInt(1))
+				cursor.Add(cursor, big.NewInt(1))
+			}
+		case "last":
+			for len(candidates) < batchSize && cursor.Cmp(first) >= 0 {
+				if r := ipallocationexcluded(cursor, excludeRanges); r != nil {
+					cursor.Sub(r[0], big.NewInt(1))
+					continue
+				}
+
+				candidates = append(candidates, fmt.Sprintf(hexFormat, cursor))
+				cursor.Sub(cursor, big.NewInt(1))
+			}
+		default:
+			for len(candidates) < batchSize && cursor.Cmp(last) <= 0 {
+				if r := ipallocationexcluded(cursor, excludeRanges); r != nil {
+					cursor.Add(r[1], big.NewInt(1))
+					continue
+				}
+
+				candidates = append(candidates, fmt.Sprintf(hexFormat, cursor))
+				cursor.Add(cursor, big.NewInt(1))
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		free, err := ipaddressfilterused(subnetInfo["id"].(string), candidates, v6, meta)
+
+		if err != nil {
+			return []string{}, err
+		}
+
+		for _, hexAddr := range free {
+			addr := bigintoip(hexiptobigint(hexAddr), v6)
+			tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IP address: %s\n", addr))
+			addresses = append(addresses, addr)
+		}
+	}
+
+	if len(addresses) == 0 {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free IP address in subnet (oid): %s\n", subnetInfo["id"].(string)))
+	}
+
+	return addresses, nil
+}
+
+// Return the IP addresses registered within the given boundaries of a subnet, ordered by address
+// Or an empty table in case of failure
+func ipaddressrangelist(subnetID string, startHexAddr string, endHexAddr string, meta interface{}) ([](map[string]interface{}), error) {
+	s := meta.(*SOLIDserver)
+
+	const pageSize = 1000
+
+	addresses := [](map[string]interface{}){}
+
+	for offset := 0; ; offset += pageSize {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("WHERE", "subnet_id='"+subnetID+"' AND type='ip' AND ip_addr >= '"+startHexAddr+"' AND ip_addr <= '"+endHexAddr+"'")
+		parameters.Add("ORDERBY", "ip_addr")
+		parameters.Add("limit", strconv.Itoa(pageSize))
+		parameters.Add("offset", strconv.Itoa(offset))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", "rest/ip_address_list", &parameters)
+
+		if err != nil {
+			return [](map[string]interface{}){}, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// No more addresses
+		if resp.StatusCode == 204 || len(buf) == 0 {
+			break
+		}
+
+		if resp.StatusCode != 200 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return [](map[string]interface{}){}, fmt.Errorf("SOLIDServer - Unable to list IP addresses of subnet (oid): %s (%s)\n", subnetID, errMsg)
+			}
+
+			return [](map[string]interface{}){}, fmt.Errorf("SOLIDServer - Unable to list IP addresses of subnet (oid): %s\n", subnetID)
+		}
+
+		addresses = append(addresses, buf...)
+
+		if len(buf) < pageSize {
+			break
+		}
+	}
+
+	return addresses, nil
+}
+
+// Return the first address of a block of consecutive free IP addresses of the given size
+// Within the provided subnet and optional pool
+// Or an empty string in case of failure
+func ipaddressfindfreerange(subnetInfo map[string]interface{}, poolInfo map[string]interface{}, size int, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Network and broadcast addresses are never allocated
+	first := int64(iptolong(hexiptoip(subnetInfo["start_hex_addr"].(string)))) + 1
+	last := int64(iptolong(hexiptoip(subnetInfo["end_hex_addr"].(string)))) - 1
+
+	if poolInfo != nil {
+		if poolStart := int64(iptolong(hexiptoip(poolInfo["start_hex_addr"].(string)))); poolStart > first {
+			first = poolStart
+		}
+		if poolEnd := int64(iptolong(hexiptoip(poolInfo["end_hex_addr"].(string)))); poolEnd < last {
+			last = poolEnd
+		}
+	}
+
+	if last-first+1 < int64(size) {
+		return "", fmt.Errorf("SOLIDServer - Unable to find %d consecutive free IP addresses in subnet (oid): %s\n", size, subnetInfo["id"].(string))
+	}
+
+	used, err := ipaddressrangelist(subnetInfo["id"].(string), iptohexip(longtoip(uint32(first))), iptohexip(longtoip(uint32(last))), meta)
+
+	if err != nil {
+		return "", err
+	}
+
+	candidate := first
+
+	for _, addr := range used {
+		if hexAddr, hexAddrExist := addr["ip_addr"].(string); hexAddrExist {
+			usedAddr := int64(iptolong(hexiptoip(hexAddr)))
+
+			if usedAddr < candidate {
+				continue
+			}
+
+			if usedAddr-candidate >= int64(size) {
+				break
+			}
+
+			candidate = usedAddr + 1
+		}
+	}
+
+	if last-candidate+1 >= int64(size) {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IP address range: %s - %s\n", longtoip(uint32(candidate)), longtoip(uint32(candidate+int64(size)-1))))
+		return longtoip(uint32(candidate)), nil
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to find %d consecutive free IP addresses in subnet (oid): %s\n", size, subnetInfo["id"].(string))
+}
+
+// Return an available vlan from specified vlmdomain_name
+// Or an empty table strings in case of failure
+func vlanidfindfree(vlmdomainName string, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
 	// Building parameters
 	parameters := url.Values{}
+	parameters.Add("limit", "16")
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet_name='" + strings.ToLower(subnetName) + "'"
-
-	if terminal {
-		whereClause += "AND is_terminal='1'"
+	if s.Version < 700 {
+		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND row_enabled='2'")
 	} else {
-		whereClause += "AND is_terminal='0'"
+		parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"' AND type='free'")
 	}
 
-	parameters.Add("WHERE", whereClause)
-
-	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
+	// Sending the creation request
+	resp, body, err := s.Request("get", "rest/vlmvlan_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -738,68 +1105,137 @@ func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta in
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet_id"].(string); subnetIDExist {
-				res["id"] = subnetID
+			vnIDs := []string{}
 
-				if subnetName, subnetNameExist := buf[0]["subnet_name"].(string); subnetNameExist {
-					res["name"] = subnetName
-				}
+			for i := range buf {
+				if s.Version < 700 {
+					if vnID, vnIDExist := buf[i]["vlmvlan_vlan_id"].(string); vnIDExist {
+						tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %s\n", vnID))
+						vnIDs = append(vnIDs, vnID)
+					}
+				} else {
+					if startVlanID, startVlanIDExist := buf[i]["free_start_vlan_id"].(string); startVlanIDExist {
+						if endVlanID, endVlanIDExist := buf[i]["free_end_vlan_id"].(string); endVlanIDExist {
+							vnID, _ := strconv.Atoi(startVlanID)
+							maxVnID, _ := strconv.Atoi(endVlanID)
 
-				if subnetSize, subnetSizeExist := buf[0]["subnet_size"].(string); subnetSizeExist {
-					res["size"], _ = strconv.Atoi(subnetSize)
-					res["prefix_length"] = sizetoprefixlength(res["size"].(int))
+							j := 0
+							for vnID < maxVnID && j < 8 {
+								tflog.Debug(s.Ctx, fmt.Sprintf("Suggested vlan ID: %d\n", vnID))
+								vnIDs = append(vnIDs, strconv.Itoa(vnID))
+								vnID++
+								j++
+							}
+						}
+					}
 				}
+			}
+			return vnIDs, nil
+		}
+	}
 
-				if subnetStartAddr, subnetStartAddrExist := buf[0]["start_ip_addr"].(string); subnetStartAddrExist {
-					res["start_hex_addr"] = subnetStartAddr
-					res["start_addr"] = hexiptoip(subnetStartAddr)
-				}
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find a free vlan ID in vlan domain: %s\n", vlmdomainName))
 
-				if subnetEndAddr, subnetEndAddrExist := buf[0]["end_ip_addr"].(string); subnetEndAddrExist {
-					res["end_hex_addr"] = subnetEndAddr
-					res["end_addr"] = hexiptoip(subnetEndAddr)
-				}
+	return []string{}, err
+}
 
-				if subnetTerminal, subnetTerminalExist := buf[0]["is_terminal"].(string); subnetTerminalExist {
-					res["terminal"] = subnetTerminal
-				}
+// Return the oid of a space from site_name
+// Or an empty string in case of failure
+func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
 
-				if subnetLvl, subnetLvlExist := buf[0]["subnet_level"].(string); subnetLvlExist {
-					res["level"] = subnetLvl
-				}
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_name='"+strings.ToLower(siteName)+"'")
 
-				return res, nil
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_site_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if siteID, siteIDExist := buf[0]["site_id"].(string); siteIDExist {
+				return siteID, nil
 			}
 		}
+	}
 
-		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet: %s\n", subnetName)
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP space: %s\n", siteName))
+
+	return "", err
+}
+
+// Return the name of the parent space of an IP space from its information
+// Or an empty string if the space doesn't belong to a space hierarchy
+func ipsiteparentname(siteInfo map[string]interface{}) string {
+	if parentID, parentIDExist := siteInfo["parent_site_id"].(string); !parentIDExist || parentID == "" || parentID == "0" {
+		return ""
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", subnetName))
+	parentName, _ := siteInfo["parent_site_name"].(string)
 
-	return nil, err
+	return parentName
 }
 
-// Return the oid of a subnet from site_id, subnet_name and is_terminal property
-// Or an empty string in case of failure
-func ip6subnetidbyname(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
+// Return the names of the child spaces of an IP space from site_id
+// Or an empty table of string in case of failure
+func ipsitechildrennames(siteID string, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
+	children := []string{}
 
 	// Building parameters
 	parameters := url.Values{}
+	parameters.Add("WHERE", "parent_site_id='"+siteID+"'")
+	parameters.Add("ORDERBY", "site_name")
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + strings.ToLower(subnetName) + "'"
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_site_list", &parameters)
 
-	if terminal {
-		whereClause += "AND is_terminal='1'"
-	} else {
-		whereClause += "AND is_terminal='0'"
+	if err != nil {
+		return children, err
 	}
 
-	parameters.Add("WHERE", whereClause)
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// An empty answer means that the space has no child
+	if resp.StatusCode == 204 || (resp.StatusCode == 200 && len(buf) == 0) {
+		return children, nil
+	}
+
+	if resp.StatusCode != 200 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return children, fmt.Errorf("SOLIDServer - Unable to list the child spaces of IP space (oid): %s (%s)\n", siteID, errMsg)
+			}
+		}
+
+		return children, fmt.Errorf("SOLIDServer - Unable to list the child spaces of IP space (oid): %s\n", siteID)
+	}
+
+	for i := 0; i < len(buf); i++ {
+		if name, nameExist := buf[i]["site_name"].(string); nameExist {
+			children = append(children, name)
+		}
+	}
+
+	return children, nil
+}
+
+// Return the oid of a vlan domain from vlmdomain_name
+// Or an empty string in case of failure
+func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "vlmdomain_name='"+strings.ToLower(vlmdomainName)+"'")
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_block6_subnet6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/vlmdomain_name", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
@@ -807,44 +1243,1178 @@ func ip6subnetidbyname(siteID string, subnetName string, terminal bool, meta int
 
 		// Checking the answer
 		if resp.StatusCode == 200 && len(buf) > 0 {
-			if subnetID, subnetIDExist := buf[0]["subnet6_id"].(string); subnetIDExist {
-				return subnetID, nil
+			if vlmdomainID, vlmdomainIDExist := buf[0]["vlmdomain_id"].(string); vlmdomainIDExist {
+				return vlmdomainID, nil
 			}
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 subnet: %s\n", subnetName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find vlan domain: %s\n", vlmdomainName))
+
+	return "", err
+}
+
+// Parse a reference to an IP subnet, block or pool
+// A reference is either an oid (ex: subnet_id:42), a CIDR prefix (ex: 10.0.0.0/24) or an object name
+// Oids are only accepted for the expected kind of object (subnet or pool), IPv6 ones being suffixed (ex: subnet6_id:7)
+// Return the oid or the prefix (its start address in hexa and its length) found, both empty for a name
+func ipreferenceparse(reference string, kind string, v6 bool) (string, string, int) {
+	if v6 {
+		kind += "6"
+	}
+
+	if match := regexp.MustCompile("^(?:" + kind + "_)?id:([0-9]+)$").FindStringSubmatch(reference); match != nil {
+		return match[1], "", 0
+	}
+
+	if !strings.Contains(reference, "/") {
+		return "", "", 0
+	}
+
+	prefix, prefixErr := netaddr.ParseIPPrefix(reference)
+
+	if prefixErr != nil || prefix.IP().Is6() != v6 {
+		return "", "", 0
+	}
+
+	start := prefix.Masked().IP().String()
+
+	if v6 {
+		return "", ip6tohexip6(shortip6tolongip6(start)), int(prefix.Bits())
+	}
+
+	return "", iptohexip(start), int(prefix.Bits())
+}
+
+// Return the where clause matching the IP or IPv6 subnets (or blocks) designated by a reference
+// Subnets may be referenced by oid, prefix or name
+func ipsubnetreferenceclause(subnetName string, v6 bool) string {
+	oid, startHexAddr, prefixLength := ipreferenceparse(subnetName, "subnet", v6)
+
+	if v6 {
+		if oid != "" {
+			return "subnet6_id='" + oid + "'"
+		} else if startHexAddr != "" {
+			return "start_ip6_addr='" + startHexAddr + "' AND subnet6_prefix='" + strconv.Itoa(prefixLength) + "'"
+		}
+
+		return "subnet6_name='" + strings.ToLower(subnetName) + "'"
+	}
+
+	if oid != "" {
+		return "subnet_id='" + oid + "'"
+	} else if startHexAddr != "" {
+		return "start_ip_addr='" + startHexAddr + "' AND subnet_size='" + strconv.Itoa(prefixlengthtosize(prefixLength)) + "'"
+	}
+
+	return "subnet_name='" + strings.ToLower(subnetName) + "'"
+}
+
+// Refresh an attribute referencing an IP subnet, block or pool with the name of the object
+// References made by oid or prefix are kept as is, they always point to the same object
+func ipreferenceset(d *schema.ResourceData, key string, name string) {
+	reference := d.Get(key).(string)
+	kind := "subnet"
+
+	if key == "pool" {
+		kind = "pool"
+	}
+
+	if oid, _, _ := ipreferenceparse(reference, kind, false); oid != "" {
+		return
+	}
+
+	if oid, _, _ := ipreferenceparse(reference, kind, true); oid != "" {
+		return
+	}
+
+	if _, prefixErr := netaddr.ParseIPPrefix(reference); prefixErr == nil {
+		return
+	}
+
+	d.Set(key, name)
+}
+
+// Return the oid of a pool from site_id and pool_name
+// Or an empty string in case of failure
+func ippoolinfobyname(siteID string, poolName string, subnetName string, meta interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "site_id='" + siteID + "'"
+
+	// Pools may be referenced by oid, range (as a CIDR prefix) or name
+	if oid, startHexAddr, prefixLength := ipreferenceparse(poolName, "pool", false); oid != "" {
+		whereClause += " AND pool_id='" + oid + "'"
+	} else if startHexAddr != "" {
+		whereClause += " AND start_ip_addr='" + startHexAddr + "' AND pool_size='" + strconv.Itoa(prefixlengthtosize(prefixLength)) + "'"
+	} else {
+		whereClause += " AND pool_name='" + strings.ToLower(poolName) + "'"
+	}
+
+	// So may be their parent subnet
+	if oid, startHexAddr, prefixLength := ipreferenceparse(subnetName, "subnet", false); oid != "" {
+		whereClause += " AND subnet_id='" + oid + "'"
+	} else if startHexAddr != "" {
+		whereClause += " AND subnet_start_ip_addr='" + startHexAddr + "' AND subnet_size='" + strconv.Itoa(prefixlengthtosize(prefixLength)) + "'"
+	} else {
+		whereClause += " AND subnet_name='" + strings.ToLower(subnetName) + "'"
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_pool_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return nil, fmt.Errorf("SOLIDServer - Ambiguous IP pool: %s, %d pools match, reference it by range or by oid (pool_id:<oid>) instead\n", poolName, len(buf))
+		}
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if poolID, poolIDExist := buf[0]["pool_id"].(string); poolIDExist {
+				res["id"] = poolID
+
+				if poolName, poolNameExist := buf[0]["pool_name"].(string); poolNameExist {
+					res["name"] = poolName
+				}
+
+				if poolSize, poolSizeExist := buf[0]["pool_size"].(string); poolSizeExist {
+					res["size"], _ = strconv.Atoi(poolSize)
+				}
+
+				if poolStartAddr, poolStartAddrExist := buf[0]["start_ip_addr"].(string); poolStartAddrExist {
+					res["start_hex_addr"] = poolStartAddr
+					res["start_addr"] = hexiptoip(poolStartAddr)
+				}
+
+				if poolEndAddr, poolEndAddrExist := buf[0]["end_ip_addr"].(string); poolEndAddrExist {
+					res["end_hex_addr"] = poolEndAddr
+					res["end_addr"] = hexiptoip(poolEndAddr)
+				}
+
+				return res, nil
+			}
+		}
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP pool: %s\n", poolName))
+
+	return nil, err
+}
+
+// Return a map of information about a subnet from site_id, subnet_name and is_terminal property
+// Or nil in case of failure
+func ipsubnetinfobyname(siteID string, subnetName string, terminal bool, meta interface{}) (map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+
+	whereClause := "site_id='" + siteID + "' AND " + ipsubnetreferenceclause(subnetName, false)
+
+	if terminal {
+		whereClause += "AND is_terminal='1'"
+	} else {
+		whereClause += "AND is_terminal='0'"
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return nil, fmt.Errorf("SOLIDServer - Ambiguous IP subnet: %s, %d subnets match, reference it by prefix or by oid (subnet_id:<oid>) instead\n", subnetName, len(buf))
+		}
+
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if res := ipsubnetinfofromentry(buf[0]); res != nil {
+				return res, nil
+			}
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet: %s\n", subnetName)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", subnetName))
+
+	return nil, err
+}
+
+// Build the subnet information map from an ip_block_subnet_list entry
+// Or nil if the entry is not a valid subnet
+func ipsubnetinfofromentry(entry map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if subnetID, subnetIDExist := entry["subnet_id"].(string); subnetIDExist {
+		res["id"] = subnetID
+
+		if subnetName, subnetNameExist := entry["subnet_name"].(string); subnetNameExist {
+			res["name"] = subnetName
+		}
+
+		if subnetSize, subnetSizeExist := entry["subnet_size"].(string); subnetSizeExist {
+			res["size"], _ = strconv.Atoi(subnetSize)
+			res["prefix_length"] = sizetoprefixlength(res["size"].(int))
+		}
+
+		if subnetStartAddr, subnetStartAddrExist := entry["start_ip_addr"].(string); subnetStartAddrExist {
+			res["start_hex_addr"] = subnetStartAddr
+			res["start_addr"] = hexiptoip(subnetStartAddr)
+		}
+
+		if subnetEndAddr, subnetEndAddrExist := entry["end_ip_addr"].(string); subnetEndAddrExist {
+			res["end_hex_addr"] = subnetEndAddr
+			res["end_addr"] = hexiptoip(subnetEndAddr)
+		}
+
+		if subnetTerminal, subnetTerminalExist := entry["is_terminal"].(string); subnetTerminalExist {
+			res["terminal"] = subnetTerminal
+		}
+
+		if subnetLvl, subnetLvlExist := entry["subnet_level"].(string); subnetLvlExist {
+			res["level"] = subnetLvl
+		}
+
+		if parentID, parentIDExist := entry["parent_subnet_id"].(string); parentIDExist {
+			res["parent_id"] = parentID
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// Ensure an IP subnet can be resized in place to the given prefix length
+// Its network address remains unchanged, so it must be aligned on the new prefix
+// And every child object must stand within the new range, apart from the ignored addresses about to be released
+func ipsubnetresizecheck(subnetID string, address string, prefixLength int, ignored []string, meta interface{}) error {
+	size := prefixlengthtosize(prefixLength)
+
+	if size == -1 {
+		return fmt.Errorf("Unable to resize IP subnet, invalid prefix size: /%d\n", prefixLength)
+	}
+
+	start := iptolong(address)
+
+	if start%uint32(size) != 0 {
+		return fmt.Errorf("Unable to resize IP subnet %s in place, it is not a valid network address for a /%d prefix\n", address, prefixLength)
+	}
+
+	endHexAddr := iptohexip(longtoip(start + uint32(size) - 1))
+	addressWhereClause := "subnet_id='" + subnetID + "' AND type='ip' AND ip_addr>'" + endHexAddr + "'"
+
+	for _, ip := range ignored {
+		addressWhereClause += " AND ip_addr!='" + iptohexip(ip) + "'"
+	}
+
+	for _, check := range []struct {
+		service     string
+		whereClause string
+		kind        string
+	}{
+		{"ip_address_count", addressWhereClause, "IP address(es)"},
+		{"ip_pool_count", "subnet_id='" + subnetID + "' AND end_ip_addr>'" + endHexAddr + "'", "pool(s)"},
+		{"ip_block_subnet_count", "parent_subnet_id='" + subnetID + "' AND end_ip_addr>'" + endHexAddr + "'", "subnet(s)"},
+	} {
+		count, err := objectcount(check.service, check.whereClause, meta)
+
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return fmt.Errorf("Unable to resize IP subnet %s/%d in place, %d %s would fall outside of the new range (%s - %s)\n", address, prefixLength, count, check.kind, address, hexiptoip(endHexAddr))
+		}
+	}
+
+	return nil
+}
+
+// Ensure an IPv6 subnet can be resized in place to the given prefix length
+// Its network address remains unchanged, so it must be aligned on the new prefix
+// And every child object must stand within the new range, apart from the ignored addresses about to be released
+func ip6subnetresizecheck(subnetID string, address string, prefixLength int, ignored []string, meta interface{}) error {
+	if prefixLength < 0 || prefixLength > 128 {
+		return fmt.Errorf("Unable to resize IPv6 subnet, invalid prefix size: /%d\n", prefixLength)
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(128-prefixLength))
+	start := iptobigint(address, true)
+
+	if start == nil || new(big.Int).Mod(start, size).Sign() != 0 {
+		return fmt.Errorf("Unable to resize IPv6 subnet %s in place, it is not a valid network address for a /%d prefix\n", address, prefixLength)
+	}
+
+	end := new(big.Int).Sub(new(big.Int).Add(start, size), big.NewInt(1))
+	endHexAddr := fmt.Sprintf("%032x", end)
+	addressWhereClause := "subnet6_id='" + subnetID + "' AND type='ip6' AND ip6_addr>'" + endHexAddr + "'"
+
+	for _, ip := range ignored {
+		addressWhereClause += " AND ip6_addr!='" + ip6tohexip6(shortip6tolongip6(ip)) + "'"
+	}
+
+	for _, check := range []struct {
+		service     string
+		whereClause string
+		kind        string
+	}{
+		{"ip6_address6_count", addressWhereClause, "IPv6 address(es)"},
+		{"ip6_pool6_count", "subnet6_id='" + subnetID + "' AND end_ip6_addr>'" + endHexAddr + "'", "pool(s)"},
+		{"ip6_block6_subnet6_count", "parent_subnet6_id='" + subnetID + "' AND end_ip6_addr>'" + endHexAddr + "'", "subnet(s)"},
+	} {
+		count, err := objectcount(check.service, check.whereClause, meta)
+
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return fmt.Errorf("Unable to resize IPv6 subnet %s/%d in place, %d %s would fall outside of the new range (%s - %s)\n", address, prefixLength, count, check.kind, address, bigintoip(end, true))
+		}
+	}
+
+	return nil
+}
+
+// Ensure an IP pool can be moved or resized in place to the given range
+// Every address allocated within the pool must stand within the new range
+// Addresses are provided as hexadecimal strings
+func ippoolmovecheck(poolID string, startHexAddr string, endHexAddr string, v6 bool, meta interface{}) error {
+	service := "ip_address_count"
+	whereClause := "pool_id='" + poolID + "' AND type='ip' AND (ip_addr<'" + startHexAddr + "' OR ip_addr>'" + endHexAddr + "')"
+	kind := "IP"
+	start := hexiptoip(startHexAddr)
+	end := hexiptoip(endHexAddr)
+
+	if v6 {
+		service = "ip6_address6_count"
+		whereClause = "pool6_id='" + poolID + "' AND type='ip6' AND (ip6_addr<'" + startHexAddr + "' OR ip6_addr>'" + endHexAddr + "')"
+		kind = "IPv6"
+		start = hexip6toip6(startHexAddr)
+		end = hexip6toip6(endHexAddr)
+	}
+
+	count, err := objectcount(service, whereClause, meta)
+
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("Unable to move %s pool in place, %d allocated address(es) would fall outside of the new range (%s - %s)\n", kind, count, start, end)
+	}
+
+	return nil
+}
+
+// Return the address standing at the given offset within a subnet
+// A negative offset is counted backward from the end of the subnet (IPv4 broadcast address excluded)
+func subnetaddressatoffset(address string, prefixLength int, offset int, v6 bool) string {
+	if v6 {
+		start := iptobigint(address, true)
+
+		if start == nil {
+			return ""
+		}
+
+		if offset < 0 {
+			start.Add(start, prefix6lengthtosize(int64(prefixLength)))
+		}
+
+		return bigintoip(start.Add(start, big.NewInt(int64(offset))), true)
+	}
+
+	if offset < 0 {
+		return longtoip(iptolong(address) + uint32(prefixlengthtosize(prefixLength)) - uint32(abs(offset)) - 1)
+	}
+
+	return longtoip(iptolong(address) + uint32(offset))
+}
+
+// Ensure the reserved addresses of a subnet use distinct offsets, apart from the gateway one
+func subnetreservedcheck(reserved []interface{}, gatewayOffset int) error {
+	offsets := map[int]bool{}
+
+	for _, r := range reserved {
+		offset := r.(map[string]interface{})["offset"].(int)
+
+		if offset == 0 {
+			return fmt.Errorf("reserved address offset can't be 0 (network address)")
+		}
+
+		if offset == gatewayOffset {
+			return fmt.Errorf("reserved address offset %d is already used by the gateway", offset)
+		}
+
+		if offsets[offset] {
+			return fmt.Errorf("reserved address offset %d is used more than once", offset)
+		}
+
+		offsets[offset] = true
+	}
+
+	return nil
+}
+
+// Return the configured reserved addresses of a subnet along with their computed address
+func subnetreservedaddresses(d *schema.ResourceData, v6 bool) []map[string]interface{} {
+	reserved := []map[string]interface{}{}
+
+	for _, r := range d.Get("reserved_addresses").([]interface{}) {
+		entry := r.(map[string]interface{})
+
+		reserved = append(reserved, map[string]interface{}{
+			"offset":  entry["offset"].(int),
+			"name":    entry["name"].(string),
+			"class":   entry["class"].(string),
+			"mac":     entry["mac"].(string),
+			"address": subnetaddressatoffset(d.Get("address").(string), d.Get("prefix_size").(int), entry["offset"].(int), v6),
+		})
+	}
+
+	return reserved
+}
+
+// Return the previously registered reserved addresses of a subnet that are no longer expected
+// Every reserved address is returned when destroying the subnet
+func subnetreservedreleasing(d *schema.ResourceData, destroy bool, v6 bool) []map[string]interface{} {
+	releasing := []map[string]interface{}{}
+	expected := map[string]bool{}
+
+	if !destroy {
+		for _, r := range subnetreservedaddresses(d, v6) {
+			expected[r["address"].(string)] = true
+		}
+	}
+
+	oldReserved, _ := d.GetChange("reserved_addresses")
+
+	for _, r := range oldReserved.([]interface{}) {
+		entry := r.(map[string]interface{})
+		address, _ := entry["address"].(string)
+
+		if address == "" || expected[address] {
+			continue
+		}
+
+		releasing = append(releasing, entry)
+	}
+
+	return releasing
+}
+
+// Delete the given reserved addresses of a subnet
+// Return the ones actually deleted, even in case of failure, so they can be restored
+func subnetreservedrelease(d *schema.ResourceData, releasing []map[string]interface{}, v6 bool, meta interface{}) ([]map[string]interface{}, error) {
+	service := "rest/ip_delete"
+	released := []map[string]interface{}{}
+
+	if v6 {
+		service = "rest/ip6_address6_delete"
+	}
+
+	for _, r := range releasing {
+		address := r["address"].(string)
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_name", d.Get("space").(string))
+		parameters.Add("hostaddr", address)
+
+		if err := objectdelete(service, &parameters, meta); err != nil {
+			return released, fmt.Errorf("Unable to delete reserved address: %s (%s)\n", address, err)
+		}
+
+		released = append(released, r)
+	}
+
+	return released, nil
+}
+
+// Register again the reserved addresses of a subnet released by subnetreservedrelease
+func subnetreservedrestore(d *schema.ResourceData, released []map[string]interface{}, v6 bool, meta interface{}) error {
+	service := "rest/ip_add"
+	prefix := "ip"
+	failed := []string{}
+
+	if v6 {
+		service = "rest/ip6_address6_add"
+		prefix = "ip6"
+	}
+
+	for _, r := range released {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_name", d.Get("space").(string))
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("hostaddr", r["address"].(string))
+		parameters.Add(prefix+"_name", r["name"].(string))
+		parameters.Add(prefix+"_class_name", r["class"].(string))
+
+		if r["mac"].(string) != "" {
+			parameters.Add("mac_addr", r["mac"].(string))
+		}
+
+		if _, err := objectadd(service, &parameters, meta); err != nil {
+			failed = append(failed, r["address"].(string)+" ("+err.Error()+")")
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Unable to register again reserved address(es): %s\n", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// Register or update the expected reserved addresses of a subnet
+func subnetreservedapply(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	service := "rest/ip_add"
+	prefix := "ip"
+	existing := map[string]bool{}
+
+	if v6 {
+		service = "rest/ip6_address6_add"
+		prefix = "ip6"
+	}
+
+	oldReserved, _ := d.GetChange("reserved_addresses")
+
+	for _, r := range oldReserved.([]interface{}) {
+		if address, addressExist := r.(map[string]interface{})["address"].(string); addressExist && address != "" {
+			existing[address] = true
+		}
+	}
+
+	reserved := subnetreservedaddresses(d, v6)
+	applied := []map[string]interface{}{}
+
+	for _, r := range reserved {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_name", d.Get("space").(string))
+		parameters.Add("hostaddr", r["address"].(string))
+		parameters.Add(prefix+"_name", r["name"].(string))
+		parameters.Add(prefix+"_class_name", r["class"].(string))
+
+		if existing[r["address"].(string)] {
+			parameters.Add("add_flag", "edit_only")
+		} else {
+			parameters.Add("add_flag", "new_only")
+		}
+
+		if r["mac"].(string) != "" {
+			parameters.Add("mac_addr", r["mac"].(string))
+		}
+
+		if _, err := objectadd(service, &parameters, meta); err != nil {
+			d.Set("reserved_addresses", applied)
+			return fmt.Errorf("Unable to register reserved address: %s (%s)\n", r["address"].(string), err)
+		}
+
+		applied = append(applied, r)
+	}
+
+	d.Set("reserved_addresses", applied)
+
+	return nil
+}
+
+// Refresh the reserved addresses of a subnet, the ones missing from SOLIDserver are dropped
+func subnetreservedread(d *schema.ResourceData, subnetID string, v6 bool, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+	reserved := d.Get("reserved_addresses").([]interface{})
+
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	hexAddresses := []string{}
+
+	for _, r := range reserved {
+		address, _ := r.(map[string]interface{})["address"].(string)
+
+		if v6 {
+			hexAddresses = append(hexAddresses, ip6tohexip6(shortip6tolongip6(address)))
+		} else {
+			hexAddresses = append(hexAddresses, iptohexip(address))
+		}
+	}
+
+	service := "rest/ip_address_list"
+	whereClause := "subnet_id='" + subnetID + "' AND type='ip' AND ip_addr IN ('" + strings.Join(hexAddresses, "','") + "')"
+	keys := []string{"ip_addr", "name", "ip_class_name", "mac_addr"}
+
+	if v6 {
+		service = "rest/ip6_address6_list"
+		whereClause = "subnet6_id='" + subnetID + "' AND type='ip6' AND ip6_addr IN ('" + strings.Join(hexAddresses, "','") + "')"
+		keys = []string{"ip6_addr", "ip6_name", "ip6_class_name", "ip6_mac_addr"}
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to list reserved addresses: %s\n", errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to list reserved addresses\n")
+	}
+
+	found := map[string]map[string]interface{}{}
+
+	for i := 0; i < len(buf); i++ {
+		if addr, addrExist := buf[i][keys[0]].(string); addrExist {
+			found[strings.ToLower(addr)] = buf[i]
+		}
+	}
+
+	computed := []map[string]interface{}{}
+
+	for i, r := range reserved {
+		entry := r.(map[string]interface{})
+
+		if addr, addrExist := found[strings.ToLower(hexAddresses[i])]; addrExist {
+			entry["name"], _ = addr[keys[1]].(string)
+			entry["class"], _ = addr[keys[2]].(string)
+
+			if mac, _ := addr[keys[3]].(string); !strings.HasPrefix(mac, "EIP:") {
+				entry["mac"] = mac
+			} else {
+				entry["mac"] = ""
+			}
+
+			computed = append(computed, entry)
+		}
+	}
+
+	d.Set("reserved_addresses", computed)
+
+	return nil
+}
+
+// Return the DNS records (type, name and value) expected for the gateway of a subnet
+func subnetgatewaydnsrecords(gateway string, name string, forward bool, reverse bool, v6 bool) [][]string {
+	records := [][]string{}
+
+	if gateway == "" || name == "" {
+		return records
+	}
+
+	if forward {
+		if v6 {
+			records = append(records, []string{"AAAA", name, gateway})
+		} else {
+			records = append(records, []string{"A", name, gateway})
+		}
+	}
+
+	if reverse {
+		if v6 {
+			records = append(records, []string{"PTR", ip6toptr(shortip6tolongip6(gateway)), name})
+		} else {
+			records = append(records, []string{"PTR", iptoptr(gateway), name})
+		}
+	}
+
+	return records
+}
+
+// Delete the IP object of a previous gateway of a subnet
+func subnetgatewayrelease(space string, gateway string, v6 bool, meta interface{}) error {
+	service := "rest/ip_delete"
+
+	if v6 {
+		service = "rest/ip6_address6_delete"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_name", space)
+	parameters.Add("hostaddr", gateway)
+
+	if err := objectdelete(service, &parameters, meta); err != nil {
+		return fmt.Errorf("Unable to delete gateway: %s (%s)\n", gateway, err)
+	}
+
+	return nil
+}
+
+// Return the IP object of the gateway of a subnet, nil when it is not registered
+func subnetgatewayentry(space string, gateway string, v6 bool, meta interface{}) (map[string]interface{}, error) {
+	service := "rest/ip_address_list"
+	whereClause := "site_name='" + space + "' AND type='ip' AND ip_addr='" + iptohexip(gateway) + "'"
+
+	if v6 {
+		service = "rest/ip6_address6_list"
+		whereClause = "site_name='" + space + "' AND type='ip6' AND ip6_addr='" + ip6tohexip6(shortip6tolongip6(gateway)) + "'"
+	}
+
+	addresses, err := objectlistbyquery(service, whereClause, "", "", 1, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	return addresses[0], nil
+}
+
+// Register again the IP object of a released gateway with its previous name, class and class parameters
+func subnetgatewayrestore(space string, gateway string, entry map[string]interface{}, v6 bool, meta interface{}) error {
+	service := "rest/ip_add"
+	prefix := "ip"
+	nameKey := "name"
+
+	if entry == nil {
+		return nil
+	}
+
+	if v6 {
+		service = "rest/ip6_address6_add"
+		prefix = "ip6"
+		nameKey = "ip6_name"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("site_name", space)
+	parameters.Add("hostaddr", gateway)
+	parameters.Add(prefix+"_name", entrystring(entry, nameKey))
+	parameters.Add(prefix+"_class_name", entrystring(entry, prefix+"_class_name"))
+	parameters.Add(prefix+"_class_parameters", entrystring(entry, prefix+"_class_parameters"))
+
+	if _, err := objectadd(service, &parameters, meta); err != nil {
+		return fmt.Errorf("Unable to restore gateway: %s (%s)\n", gateway, err)
+	}
+
+	return nil
+}
+
+// Register or update the IP object of the gateway of a subnet along with its name, class and class parameters
+func subnetgatewayapply(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	service := "rest/ip_add"
+	prefix := "ip"
+
+	if v6 {
+		service = "rest/ip6_address6_add"
+		prefix = "ip6"
+	}
+
+	// Updating the gateway registered along with the subnet, or registering it
+	entry, err := subnetgatewayentry(d.Get("space").(string), d.Get("gateway").(string), v6, meta)
+
+	if err != nil {
+		return fmt.Errorf("Unable to register gateway: %s (%s)\n", d.Get("gateway").(string), err)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+
+	if entry != nil {
+		parameters.Add("add_flag", "edit_only")
+	} else {
+		parameters.Add("add_flag", "new_only")
+	}
+
+	parameters.Add("site_name", d.Get("space").(string))
+	parameters.Add("hostaddr", d.Get("gateway").(string))
+	parameters.Add(prefix+"_name", d.Get("gateway_name").(string))
+	parameters.Add(prefix+"_class_name", d.Get("gateway_class").(string))
+	parameters.Add(prefix+"_class_parameters", urlfromclassparams(d.Get("gateway_class_parameters")).Encode())
+
+	if _, err := objectadd(service, &parameters, meta); err != nil {
+		return fmt.Errorf("Unable to register gateway: %s (%s)\n", d.Get("gateway").(string), err)
+	}
+
+	return nil
+}
+
+// Check whether the name, class or class parameters of the gateway of a subnet are managed by terraform
+func subnetgatewaymanaged(d *schema.ResourceData) bool {
+	return d.Get("gateway_name").(string) != "" || d.Get("gateway_class").(string) != "" || len(d.Get("gateway_class_parameters").(map[string]interface{})) > 0
+}
+
+// Refresh the name, class and class parameters of the gateway of a subnet, when managed by terraform
+func subnetgatewayread(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	gateway := d.Get("gateway").(string)
+	currentClassParameters := d.Get("gateway_class_parameters").(map[string]interface{})
+
+	if gateway == "" || d.Get("gateway_offset").(int) == 0 || !subnetgatewaymanaged(d) {
+		return nil
+	}
+
+	keys := []string{"name", "ip_class_name", "ip_class_parameters"}
+
+	if v6 {
+		keys = []string{"ip6_name", "ip6_class_name", "ip6_class_parameters"}
+	}
+
+	entry, err := subnetgatewayentry(d.Get("space").(string), gateway, v6, meta)
+
+	if err != nil {
+		return err
+	}
+
+	computedClassParameters := map[string]string{}
+
+	// The gateway no longer exists, it will be registered again
+	if entry == nil {
+		d.Set("gateway_name", "")
+		d.Set("gateway_class", "")
+		d.Set("gateway_class_parameters", computedClassParameters)
+
+		return nil
+	}
+
+	retrievedClassParameters := entryclassparameters(entry, keys[2])
+
+	for ck := range currentClassParameters {
+		computedClassParameters[ck] = retrievedClassParameters[ck]
+	}
+
+	d.Set("gateway_name", entrystring(entry, keys[0]))
+	d.Set("gateway_class", entrystring(entry, keys[1]))
+	d.Set("gateway_class_parameters", computedClassParameters)
+
+	return nil
+}
+
+// Delete the DNS records previously registered for the gateway of a subnet
+func subnetgatewaydnsrelease(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	oldGateway, _ := d.GetChange("gateway")
+	oldName, _ := d.GetChange("gateway_name")
+	oldServer, _ := d.GetChange("gateway_dns_server")
+	oldView, _ := d.GetChange("gateway_dns_view")
+	oldForward, _ := d.GetChange("gateway_dns_forward")
+	oldReverse, _ := d.GetChange("gateway_dns_reverse")
+
+	if oldServer.(string) == "" {
+		return nil
+	}
+
+	for _, rr := range subnetgatewaydnsrecords(oldGateway.(string), oldName.(string), oldForward.(bool), oldReverse.(bool), v6) {
+		whereClause := "dns_name='" + oldServer.(string) + "' AND rr_type='" + rr[0] + "' AND rr_full_name='" + rr[1] + "' AND value1='" + rr[2] + "'"
+
+		if oldView.(string) != "" {
+			whereClause += " AND dnsview_name='" + strings.ToLower(oldView.(string)) + "'"
+		}
+
+		records, err := objectlistbyquery("rest/dns_rr_list", whereClause, "", "", 0, meta)
+
+		if err != nil {
+			return fmt.Errorf("Unable to list gateway's %s record: %s (%s)\n", rr[0], rr[1], err)
+		}
+
+		for _, record := range records {
+			// Building parameters
+			parameters := url.Values{}
+			parameters.Add("rr_id", entrystring(record, "rr_id"))
+
+			if err := objectdelete("rest/dns_rr_delete", &parameters, meta); err != nil {
+				return fmt.Errorf("Unable to delete gateway's %s record: %s (%s)\n", rr[0], rr[1], err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Register the forward and reverse DNS records of the gateway of a subnet
+func subnetgatewaydnsapply(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	server := d.Get("gateway_dns_server").(string)
+
+	if server == "" {
+		return nil
+	}
+
+	for _, rr := range subnetgatewaydnsrecords(d.Get("gateway").(string), d.Get("gateway_name").(string), d.Get("gateway_dns_forward").(bool), d.Get("gateway_dns_reverse").(bool), v6) {
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("dns_name", server)
+		parameters.Add("rr_type", rr[0])
+		parameters.Add("rr_name", rr[1])
+		parameters.Add("value1", rr[2])
+
+		if view := d.Get("gateway_dns_view").(string); view != "" {
+			parameters.Add("dnsview_name", strings.ToLower(view))
+		}
+
+		if _, err := objectadd("rest/dns_rr_add", &parameters, meta); err != nil {
+			return fmt.Errorf("Unable to register gateway's %s record: %s (%s)\n", rr[0], rr[1], err)
+		}
+	}
+
+	return nil
+}
+
+// Return the aliases of an IP address as a list of maps holding their oid, name and type
+func ipaliaseslist(addressID string, v6 bool, meta interface{}) ([]map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+	res := []map[string]interface{}{}
+	prefix := "ip"
+	kind := "IP"
+
+	if v6 {
+		prefix = "ip6"
+		kind = "IPv6"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(prefix+"_id", addressID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/"+prefix+"_alias_list", &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer, an address without any alias may answer with no content
+	if resp.StatusCode == 204 || (resp.StatusCode == 200 && len(buf) == 0) {
+		return res, nil
+	}
+
+	if resp.StatusCode != 200 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return nil, fmt.Errorf("Unable to list the aliases of %s address (oid): %s (%s)\n", kind, addressID, errMsg)
+			}
+		}
+
+		return nil, fmt.Errorf("Unable to list the aliases of %s address (oid): %s\n", kind, addressID)
+	}
+
+	for _, entry := range buf {
+		aliasID, aliasIDExist := entry[prefix+"_name_id"].(string)
+		aliasName, aliasNameExist := entry["alias_name"].(string)
+
+		if aliasIDExist && aliasNameExist {
+			res = append(res, map[string]interface{}{
+				"id":   aliasID,
+				"name": aliasName,
+				"type": strings.ToUpper(entrystring(entry, prefix+"_name_type")),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// Reconcile the aliases of an IP address with the expected alias set
+// Aliases missing from the set are deleted, the expected ones missing from SOLIDserver are created
+// Nothing is done when the aliases are ignored
+func ipaliasesapply(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+	prefix := "ip"
+	kind := "IP"
+
+	if d.Get("ignore_aliases").(bool) {
+		return nil
+	}
+
+	if v6 {
+		prefix = "ip6"
+		kind = "IPv6"
+	}
+
+	current, err := ipaliaseslist(d.Id(), v6, meta)
+
+	if err != nil {
+		return err
+	}
+
+	expected := map[string]bool{}
+
+	for _, a := range d.Get("aliases").(*schema.Set).List() {
+		alias := a.(map[string]interface{})
+		expected[strings.ToLower(alias["name"].(string))+"/"+strings.ToUpper(alias["type"].(string))] = true
+	}
+
+	// Deleting the aliases no longer expected
+	for _, alias := range current {
+		key := strings.ToLower(alias["name"].(string)) + "/" + alias["type"].(string)
+
+		if expected[key] {
+			delete(expected, key)
+			continue
+		}
+
+		parameters := url.Values{}
+		parameters.Add(prefix+"_name_id", alias["id"].(string))
+
+		if err := objectdelete("rest/"+prefix+"_alias_delete", &parameters, meta); err != nil {
+			return fmt.Errorf("Unable to delete %s alias: %s - %s (%s)\n", kind, alias["name"].(string), alias["type"].(string), err)
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Deleted %s alias (oid): %s\n", kind, alias["id"].(string)))
+	}
+
+	// Creating the missing aliases
+	for _, a := range d.Get("aliases").(*schema.Set).List() {
+		alias := a.(map[string]interface{})
+
+		if !expected[strings.ToLower(alias["name"].(string))+"/"+strings.ToUpper(alias["type"].(string))] {
+			continue
+		}
+
+		parameters := url.Values{}
+		parameters.Add(prefix+"_id", d.Id())
+		parameters.Add(prefix+"_name", alias["name"].(string))
+		parameters.Add(prefix+"_name_type", alias["type"].(string))
+
+		if _, err := objectadd("rest/"+prefix+"_alias_add", &parameters, meta); err != nil {
+			return fmt.Errorf("Unable to create %s alias: %s - %s (%s)\n", kind, alias["name"].(string), alias["type"].(string), err)
+		}
+	}
+
+	return nil
+}
+
+// Refresh the alias set of an IP address from SOLIDserver, unless the aliases are ignored
+func ipaliasesread(d *schema.ResourceData, v6 bool, meta interface{}) error {
+	if d.Get("ignore_aliases").(bool) {
+		return d.Set("aliases", []interface{}{})
+	}
+
+	current, err := ipaliaseslist(d.Id(), v6, meta)
+
+	if err != nil {
+		return err
+	}
+
+	aliases := []interface{}{}
+
+	for _, alias := range current {
+		aliases = append(aliases, map[string]interface{}{
+			"name": alias["name"],
+			"type": alias["type"],
+		})
+	}
+
+	return d.Set("aliases", aliases)
+}
+
+// Rename and resize an IP subnet in place
+func ipsubnetresize(subnetID string, subnetName string, address string, prefixLength int, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", subnetID)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("subnet_name", subnetName)
+	parameters.Add("subnet_addr", address)
+	parameters.Add("subnet_prefix", strconv.Itoa(prefixLength))
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip_subnet_add", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		tflog.Debug(s.Ctx, fmt.Sprintf("Resized IP subnet (oid): %s to %s/%d\n", subnetID, address, prefixLength))
+		return nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s to %s/%d (%s)\n", subnetName, address, prefixLength, errMsg)
+		}
+	}
 
-	return "", err
+	return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s to %s/%d\n", subnetName, address, prefixLength)
 }
 
-// Return the oid of a pool from site_id and pool_name
-// Or an empty string in case of failure
-func ip6poolidbyname(siteID string, poolName string, subnetName string, meta interface{}) (string, error) {
+// Return the information of the IP blocks/subnets matching the given query within a site
+// Ordered according to the provided orderby clause
+// Or an empty table in case of failure
+func ipsubnetinfobyquery(siteID string, query string, tags string, orderby string, meta interface{}) ([]map[string]interface{}, error) {
 	s := meta.(*SOLIDserver)
+	res := []map[string]interface{}{}
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+strings.ToLower(poolName)+"' AND subnet6_name='"+strings.ToLower(subnetName)+"'")
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND is_terminal='0' AND ("+query+")")
+	parameters.Add("TAGS", tags)
+
+	if len(orderby) > 0 {
+		parameters.Add("ORDERBY", orderby)
+	}
 
 	// Sending the read request
-	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
+	resp, body, err := s.Request("get", "rest/ip_block_subnet_list", &parameters)
 
 	if err == nil {
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
 		// Checking the answer
-		if resp.StatusCode == 200 && len(buf) > 0 {
-			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
-				return poolID, nil
+		if resp.StatusCode == 200 || resp.StatusCode == 204 {
+			for _, entry := range buf {
+				if info := ipsubnetinfofromentry(entry); info != nil {
+					res = append(res, info)
+				}
+			}
+
+			return res, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return res, fmt.Errorf("SOLIDServer - Unable to find IP blocks matching the query: %s (%s)\n", query, errMsg)
 			}
 		}
+
+		return res, fmt.Errorf("SOLIDServer - Unable to find IP blocks matching the query: %s\n", query)
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IPv6 pool: %s\n", poolName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find IP blocks matching the query: %s\n", query))
 
-	return "", err
+	return res, err
 }
 
 // Return the oid of a pool from site_id and pool_name
@@ -855,7 +2425,28 @@ func ip6poolinfobyname(siteID string, poolName string, subnetName string, meta i
 
 	// Building parameters
 	parameters := url.Values{}
-	parameters.Add("WHERE", "site_id='"+siteID+"' AND "+"pool6_name='"+strings.ToLower(poolName)+"' AND subnet6_name='"+strings.ToLower(subnetName)+"'")
+	whereClause := "site_id='" + siteID + "'"
+
+	// Pools may be referenced by oid, range (as a CIDR prefix) or name
+	if oid, startHexAddr, prefixLength := ipreferenceparse(poolName, "pool", true); oid != "" {
+		whereClause += " AND pool6_id='" + oid + "'"
+	} else if startHexAddr != "" {
+		endAddr := new(big.Int).Add(hexiptobigint(startHexAddr), new(big.Int).Sub(prefix6lengthtosize(int64(prefixLength)), big.NewInt(1)))
+		whereClause += " AND start_ip6_addr='" + startHexAddr + "' AND end_ip6_addr='" + fmt.Sprintf("%032x", endAddr) + "'"
+	} else {
+		whereClause += " AND pool6_name='" + strings.ToLower(poolName) + "'"
+	}
+
+	// So may be their parent subnet
+	if oid, startHexAddr, prefixLength := ipreferenceparse(subnetName, "subnet", true); oid != "" {
+		whereClause += " AND subnet6_id='" + oid + "'"
+	} else if startHexAddr != "" {
+		whereClause += " AND subnet6_start_ip6_addr='" + startHexAddr + "' AND subnet6_prefix='" + strconv.Itoa(prefixLength) + "'"
+	} else {
+		whereClause += " AND subnet6_name='" + strings.ToLower(subnetName) + "'"
+	}
+
+	parameters.Add("WHERE", whereClause)
 
 	// Sending the read request
 	resp, body, err := s.Request("get", "rest/ip6_pool6_list", &parameters)
@@ -864,7 +2455,11 @@ func ip6poolinfobyname(siteID string, poolName string, subnetName string, meta i
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
-		// Checking the answer
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return nil, fmt.Errorf("SOLIDServer - Ambiguous IPv6 pool: %s, %d pools match, reference it by range or by oid (pool6_id:<oid>) instead\n", poolName, len(buf))
+		}
+
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if poolID, poolIDExist := buf[0]["pool6_id"].(string); poolIDExist {
 				res["id"] = poolID
@@ -906,7 +2501,7 @@ func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta i
 	// Building parameters
 	parameters := url.Values{}
 
-	whereClause := "site_id='" + siteID + "' AND " + "subnet6_name='" + strings.ToLower(subnetName) + "'"
+	whereClause := "site_id='" + siteID + "' AND " + ipsubnetreferenceclause(subnetName, true)
 
 	if terminal {
 		whereClause += "AND is_terminal='1'"
@@ -923,7 +2518,11 @@ func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta i
 		var buf [](map[string]interface{})
 		json.Unmarshal([]byte(body), &buf)
 
-		// Checking the answer
+		// Checking the answer, names are not unique
+		if resp.StatusCode == 200 && len(buf) > 1 {
+			return nil, fmt.Errorf("SOLIDServer - Ambiguous IPv6 subnet: %s, %d subnets match, reference it by prefix or by oid (subnet6_id:<oid>) instead\n", subnetName, len(buf))
+		}
+
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			if subnetID, subnetIDExist := buf[0]["subnet6_id"].(string); subnetIDExist {
 				res["id"] = subnetID
@@ -954,6 +2553,10 @@ func ip6subnetinfobyname(siteID string, subnetName string, terminal bool, meta i
 					res["level"] = subnetLvl
 				}
 
+				if subnetClassParameters, subnetClassParametersExist := buf[0]["subnet6_class_parameters"].(string); subnetClassParametersExist {
+					res["class_parameters"], _ = url.ParseQuery(subnetClassParameters)
+				}
+
 				return res, nil
 			}
 		}
@@ -1102,16 +2705,23 @@ func ipsubnetfindbysize(siteID string, blockID string, requestedIP string, prefi
 }
 
 // Return an available subnet address from site_id, block_id and expected subnet_size
+// Only the ones standing on a nibble boundary when alignToNibble is set (see ip6nibblealigned)
 // Or an empty string in case of failure
-func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, meta interface{}) ([]string, error) {
+func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, prefixSize int, alignToNibble bool, meta interface{}) ([]string, error) {
 	subnetAddresses := []string{}
 	s := meta.(*SOLIDserver)
+	maxFind := 16
+
+	// Only one suggested prefix out of 2, 4 or 8 stands on a nibble boundary, asking for more to keep as many candidates
+	if alignToNibble && prefixSize > 0 && prefixSize < 128 {
+		maxFind <<= uint(prefixSize % 4)
+	}
 
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("site_id", siteID)
 	parameters.Add("prefix", strconv.Itoa(prefixSize))
-	parameters.Add("max_find", "16")
+	parameters.Add("max_find", strconv.Itoa(maxFind))
 
 	// Specifying a suggested subnet IP address
 	if len(requestedIP) > 0 {
@@ -1139,6 +2749,11 @@ func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, pref
 					subnetAddresses = append(subnetAddresses, hexaddr)
 				}
 			}
+
+			if alignToNibble {
+				subnetAddresses = ip6subnetnibblefilter(subnetAddresses, prefixSize)
+			}
+
 			return subnetAddresses, nil
 		}
 	}
@@ -1148,6 +2763,56 @@ func ip6subnetfindbysize(siteID string, blockID string, requestedIP string, pref
 	return []string{}, err
 }
 
+// Return true if the IPv6 network address stands on a nibble boundary
+// A prefix whose length is a multiple of 4 (ex: /56, /60) always maps onto its own reverse zone and is always aligned
+// Other prefixes (ex: /62) must be the first one of the reverse zone of the enclosing nibble prefix (ex: /60)
+func ip6nibblealigned(hexAddr string, prefixSize int) bool {
+	start := hexiptobigint(hexAddr)
+
+	if start == nil || prefixSize < 0 || prefixSize > 128 {
+		return false
+	}
+
+	boundary := new(big.Int).Lsh(big.NewInt(1), uint(128-(prefixSize/4)*4))
+
+	return new(big.Int).Mod(start, boundary).Sign() == 0
+}
+
+// Return the IPv6 network addresses standing on a nibble boundary among the suggested ones
+func ip6subnetnibblefilter(subnetAddresses []string, prefixSize int) []string {
+	res := []string{}
+
+	for _, hexAddr := range subnetAddresses {
+		if ip6nibblealigned(hexAddr, prefixSize) {
+			res = append(res, hexAddr)
+		}
+	}
+
+	return res
+}
+
+// Return a map of information about an IPv6 delegation pool from site_id and pool name
+// Delegation pools are non terminal IPv6 subnets flagged through their class parameters
+// Or nil in case of failure
+func ip6delegationpoolinfobyname(siteID string, poolName string, meta interface{}) (map[string]interface{}, error) {
+	res, err := ip6subnetinfobyname(siteID, poolName, false, meta)
+
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	classParameters, _ := res["class_parameters"].(url.Values)
+
+	if classParameters.Get("delegation_pool") != "1" {
+		return nil, fmt.Errorf("SOLIDServer - IPv6 subnet: %s is not a delegation pool\n", poolName)
+	}
+
+	res["delegated_prefix_size"], _ = strconv.Atoi(classParameters.Get("delegated_prefix_size"))
+	res["align_to_nibble"] = classParameters.Get("delegated_align_to_nibble") == "1"
+
+	return res, nil
+}
+
 // Return the oid of a Custom DB from name
 // Or an empty string in case of failure
 func cdbnameidbyname(name string, meta interface{}) (string, error) {
@@ -1177,6 +2842,331 @@ func cdbnameidbyname(name string, meta interface{}) (string, error) {
 	return "", err
 }
 
+// Return the number of objects matching the where clause using a *_count service
+// Return -1 and an error in case of failure
+func objectcount(service string, whereClause string, meta interface{}) (int, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters for retrieving information
+	parameters := url.Values{}
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the get request
+	resp, body, err := s.Request("get", "rest/"+service, &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			if total, totalExist := buf[0]["total"].(string); totalExist {
+				count, _ := strconv.Atoi(total)
+				return count, nil
+			}
+		}
+
+		// Log the error
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return -1, fmt.Errorf("SOLIDServer - Unable to count objects using %s (%s)\n", service, errMsg)
+			}
+		}
+
+		return -1, fmt.Errorf("SOLIDServer - Unable to count objects using %s\n", service)
+	}
+
+	return -1, err
+}
+
+// Submit a creation request to the given service
+// Return the oid of the created object
+func objectadd(service string, parameters *url.Values, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", service, parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("%s", errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("unexpected answer from %s (status: %d)", service, resp.StatusCode)
+}
+
+// Submit a deletion request to the given service
+func objectdelete(service string, parameters *url.Values, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", service, parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("%s", errMsg)
+			}
+		}
+
+		return fmt.Errorf("unexpected answer from %s (status: %d)", service, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Return the objects matching the where clause from the given list service
+// An empty answer is not considered as a failure
+func objectlist(service string, whereClause string, meta interface{}) ([](map[string]interface{}), error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	if resp.StatusCode == 204 || (resp.StatusCode == 200 && len(buf) == 0) {
+		return [](map[string]interface{}){}, nil
+	}
+
+	if resp.StatusCode != 200 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return nil, fmt.Errorf("SOLIDServer - Unable to list objects using %s (%s)\n", service, errMsg)
+			}
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to list objects using %s\n", service)
+	}
+
+	return buf, nil
+}
+
+// The oid key of the objects returned by the list services, used as their default order
+var objectlistidkeys = map[string]string{
+	"rest/dns_rr_list":             "rr_id",
+	"rest/ip_address_list":         "ip_id",
+	"rest/ip6_address6_list":       "ip6_id",
+	"rest/ip_block_subnet_list":    "subnet_id",
+	"rest/ip6_block6_subnet6_list": "subnet6_id",
+	"rest/ip_pool_list":            "pool_id",
+	"rest/ip6_pool6_list":          "pool6_id",
+}
+
+// Return all the objects matching the query (and tags) from the given list service
+// Objects are retrieved by pages, up to limit objects (0 means no limit)
+func objectlistbyquery(service string, query string, tags string, orderby string, limit int, meta interface{}) ([](map[string]interface{}), error) {
+	s := meta.(*SOLIDserver)
+	objects := [](map[string]interface{}){}
+	pageSize := 1000
+
+	// Pages are only consistent with a stable order, defaulting to the oid of the objects
+	if orderby == "" {
+		orderby = objectlistidkeys[service]
+	}
+
+	for offset := 0; ; offset += pageSize {
+		if limit > 0 && limit-len(objects) < pageSize {
+			pageSize = limit - len(objects)
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("WHERE", query)
+		parameters.Add("TAGS", tags)
+		parameters.Add("ORDERBY", orderby)
+		parameters.Add("limit", strconv.Itoa(pageSize))
+		parameters.Add("offset", strconv.Itoa(offset))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", service, &parameters)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if resp.StatusCode == 204 || (resp.StatusCode == 200 && len(buf) == 0) {
+			return objects, nil
+		}
+
+		if resp.StatusCode != 200 {
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return nil, fmt.Errorf("SOLIDServer - Unable to list objects using %s (%s)\n", service, errMsg)
+				}
+			}
+
+			return nil, fmt.Errorf("SOLIDServer - Unable to list objects using %s\n", service)
+		}
+
+		objects = append(objects, buf...)
+
+		if len(buf) < pageSize || (limit > 0 && len(objects) >= limit) {
+			return objects, nil
+		}
+	}
+}
+
+// Return the string value of a key from an object retrieved from SOLIDserver
+// Or an empty string if the key is missing
+func entrystring(entry map[string]interface{}, key string) string {
+	if value, valueExist := entry[key].(string); valueExist {
+		return value
+	}
+
+	return ""
+}
+
+// Return the class parameters of an object retrieved from SOLIDserver as a map
+// Excluding the ones listed (ex: the gateway of subnets)
+func entryclassparameters(entry map[string]interface{}, key string, exclude ...string) map[string]string {
+	retrievedClassParameters, _ := url.ParseQuery(entrystring(entry, key))
+	computedClassParameters := map[string]string{}
+
+	for ck := range retrievedClassParameters {
+		if stringOffsetInSlice(ck, exclude) == -1 {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+	}
+
+	return computedClassParameters
+}
+
+// Set the address usage of an IP subnet or pool from its information
+// The used count is computed from the addresses when not reported by SOLIDserver
+// IPv6 counts are reported as strings as they may exceed 64-bit integers
+func ipusageset(d *schema.ResourceData, entry map[string]interface{}, sizeKey string, usedKey string, addressWhereClause string, v6 bool, meta interface{}) error {
+	service := "ip_address_count"
+	whereClause := addressWhereClause + " AND type='ip'"
+	leaseKey := "dhcplease_id"
+	hostKey := "dhcphost_id"
+
+	if v6 {
+		service = "ip6_address6_count"
+		whereClause = addressWhereClause + " AND type='ip6'"
+		leaseKey = "dhcplease6_id"
+		hostKey = "dhcphost6_id"
+	}
+
+	total, _ := new(big.Int).SetString(fmt.Sprintf("%v", entry[sizeKey]), 10)
+
+	if total == nil {
+		return fmt.Errorf("SOLIDServer - Unable to retrieve the size from %s\n", sizeKey)
+	}
+
+	used, _ := new(big.Int).SetString(fmt.Sprintf("%v", entry[usedKey]), 10)
+
+	if used == nil {
+		count, err := objectcount(service, whereClause, meta)
+
+		if err != nil {
+			return err
+		}
+
+		used = big.NewInt(int64(count))
+	}
+
+	leases, leasesErr := objectcount(service, whereClause+" AND "+leaseKey+">0", meta)
+
+	if leasesErr != nil {
+		return leasesErr
+	}
+
+	hosts, hostsErr := objectcount(service, whereClause+" AND "+hostKey+">0", meta)
+
+	if hostsErr != nil {
+		return hostsErr
+	}
+
+	free := new(big.Int).Sub(total, used)
+	percent := 0.0
+
+	if total.Sign() > 0 {
+		percent, _ = new(big.Float).Quo(new(big.Float).Mul(new(big.Float).SetInt(used), big.NewFloat(100)), new(big.Float).SetInt(total)).Float64()
+	}
+
+	if v6 {
+		d.Set("size", total.String())
+		d.Set("used", used.String())
+		d.Set("free", free.String())
+	} else {
+		d.Set("size", int(total.Int64()))
+		d.Set("used", int(used.Int64()))
+		d.Set("free", int(free.Int64()))
+	}
+
+	d.Set("used_percent", percent)
+	d.Set("dhcp_leases", leases)
+	d.Set("dhcp_statics", hosts)
+
+	return nil
+}
+
+// Check if an object can be deleted according to its deletion_protection attribute
+// and, when protect_non_empty is enabled, to the number of children objects it still holds
+// Children objects managed within the state are expected to be destroyed beforehand
+// Return an error if the deletion must be refused
+func deletionprotectioncheck(d *schema.ResourceData, objectType string, childrenQueries map[string]string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("Unable to delete %s: %s, deletion protection is enabled\n", objectType, d.Get("name").(string))
+	}
+
+	if !s.ProtectNonEmpty {
+		return nil
+	}
+
+	for service, whereClause := range childrenQueries {
+		count, err := objectcount(service, whereClause, meta)
+
+		if err != nil {
+			return fmt.Errorf("Unable to delete %s: %s, unable to check for remaining objects (%s)\n", objectType, d.Get("name").(string), err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("Unable to delete %s: %s, it still holds %d object(s) not managed by terraform (%s)\n", objectType, d.Get("name").(string), count, service)
+		}
+	}
+
+	return nil
+}
+
 // Update a DNS SMART member's role list
 // Return false in case of failure
 func dnssmartmembersupdate(smartName string, smartMembersRole string, meta interface{}) bool {
@@ -1618,3 +3608,81 @@ func dnsdeletefromsmart(smartName string, serverName string, meta interface{}) b
 
 	return false
 }
+
+// Build the WHERE clause looking up an IP address by address, name, MAC address or custom query
+// The lookup is restricted to the given space, or performed across all of them when none is specified
+func ipaddresslookupclause(d *schema.ResourceData, v6 bool) string {
+	clauses := []string{}
+
+	if v6 {
+		clauses = append(clauses, "type='ip6'")
+	} else {
+		clauses = append(clauses, "type='ip'")
+	}
+
+	if space := d.Get("space").(string); space != "" {
+		clauses = append(clauses, "site_name='"+space+"'")
+	}
+
+	mac := strings.ToLower(strings.ReplaceAll(d.Get("mac").(string), "-", ":"))
+
+	switch {
+	case d.Get("address").(string) != "" && v6:
+		clauses = append(clauses, "ip6_addr='"+ip6tohexip6(shortip6tolongip6(d.Get("address").(string)))+"'")
+	case d.Get("address").(string) != "":
+		clauses = append(clauses, "ip_addr='"+iptohexip(d.Get("address").(string))+"'")
+	case d.Get("name").(string) != "" && v6:
+		clauses = append(clauses, "ip6_name='"+d.Get("name").(string)+"'")
+	case d.Get("name").(string) != "":
+		clauses = append(clauses, "name='"+d.Get("name").(string)+"'")
+	case mac != "" && v6:
+		clauses = append(clauses, "ip6_mac_addr='"+mac+"'")
+	case mac != "":
+		clauses = append(clauses, "mac_addr='"+mac+"'")
+	case d.Get("query").(string) != "":
+		clauses = append(clauses, "("+d.Get("query").(string)+")")
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// Return the single IP address matching a lookup WHERE clause
+// Or an error listing the candidates when none or several addresses match
+func ipaddresslookup(whereClause string, v6 bool, meta interface{}) (map[string]interface{}, error) {
+	service := "rest/ip_address_list"
+	addrKey := "ip_addr"
+	kind := "IP"
+
+	if v6 {
+		service = "rest/ip6_address6_list"
+		addrKey = "ip6_addr"
+		kind = "IPv6"
+	}
+
+	// Retrieving a few candidates to report on ambiguous lookups
+	addresses, err := objectlistbyquery(service, whereClause, "", "", 10, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("Unable to find %s address matching: %s\n", kind, whereClause)
+	}
+
+	if len(addresses) > 1 {
+		candidates := []string{}
+
+		for _, address := range addresses {
+			if v6 {
+				candidates = append(candidates, hexip6toip6(entrystring(address, addrKey))+" ("+entrystring(address, "site_name")+")")
+			} else {
+				candidates = append(candidates, hexiptoip(entrystring(address, addrKey))+" ("+entrystring(address, "site_name")+")")
+			}
+		}
+
+		return nil, fmt.Errorf("Ambiguous %s address lookup, several addresses match: %s (%s)\n", kind, whereClause, strings.Join(candidates, ", "))
+	}
+
+	return addresses[0], nil
+}
@@ -0,0 +1,221 @@
+package solidserver
+
+import (
+	"testing"
+)
+
+func TestIPIsNetworkAddress(t *testing.T) {
+	tests := []struct {
+		ip     string
+		length int
+		want   bool
+	}{
+		{"10.0.0.0", 24, true},
+		{"10.0.0.64", 26, true},
+		{"10.0.0.32", 26, false},
+		{"10.0.0.1", 32, true},
+		{"0.0.0.0", 0, true},
+		{"10.0.0.0", 0, false},
+		{"10.0.0.0", 33, false},
+		{"10.0.0.0", -1, false},
+	}
+
+	for _, test := range tests {
+		if got := ipisnetworkaddress(test.ip, test.length); got != test.want {
+			t.Errorf("ipisnetworkaddress(%s, %d) = %t, want %t", test.ip, test.length, got, test.want)
+		}
+	}
+}
+
+func TestIPSubnetPrefixSizeValidation(t *testing.T) {
+	validate := resourceipsubnet().Schema["prefix_size"].ValidateFunc
+
+	tests := []struct {
+		prefixSize int
+		valid      bool
+	}{
+		{0, false},
+		{1, true},
+		{24, true},
+		{32, true},
+		{33, false},
+	}
+
+	for _, test := range tests {
+		if _, errs := validate(test.prefixSize, "prefix_size"); (len(errs) == 0) != test.valid {
+			t.Errorf("prefix_size %d: got errors %v, expected valid: %t", test.prefixSize, errs, test.valid)
+		}
+	}
+}
+
+func TestIPAddressAllocationOffsetValidation(t *testing.T) {
+	for _, resource := range []string{"solidserver_ip_address", "solidserver_ip6_address"} {
+		validate := Provider().ResourcesMap[resource].Schema["allocation_offset"].ValidateFunc
+
+		for offset, valid := range map[int]bool{-2: false, -1: false, 0: true, 42: true} {
+			if _, errs := validate(offset, "allocation_offset"); (len(errs) == 0) != valid {
+				t.Errorf("%s allocation_offset %d: got errors %v, expected valid: %t", resource, offset, errs, valid)
+			}
+		}
+	}
+}
+
+func TestIP6NibbleAligned(t *testing.T) {
+	tests := []struct {
+		address    string
+		prefixSize int
+		want       bool
+	}{
+		{"2001:db8:0:100::", 56, true},
+		{"2001:db8:0:10::", 60, true},
+		{"2001:db8:0:10::", 62, true},
+		{"2001:db8:0:14::", 62, false},
+		{"2001:db8:0:18::", 61, false},
+		{"2001:db8::", 64, true},
+		{"2001:db8::", 129, false},
+	}
+
+	for _, test := range tests {
+		if got := ip6nibblealigned(ip6tohexip6(shortip6tolongip6(test.address)), test.prefixSize); got != test.want {
+			t.Errorf("ip6nibblealigned(%s, %d) = %t, want %t", test.address, test.prefixSize, got, test.want)
+		}
+	}
+}
+
+func TestIPPlanAllocate(t *testing.T) {
+	entry := func(name string, prefixSize int) map[string]interface{} {
+		return map[string]interface{}{"name": name, "prefix_size": prefixSize}
+	}
+
+	tests := []struct {
+		blockStart string
+		blockEnd   string
+		used       [][2]string
+		entries    []map[string]interface{}
+		want       map[string]string
+		fail       bool
+	}{
+		// Largest first, keeping the order of the plan for subnets of the same size
+		{"10.0.0.0", "10.0.0.255", nil, []map[string]interface{}{entry("a", 26), entry("b", 25), entry("c", 26)}, map[string]string{"a": "10.0.0.128", "b": "10.0.0.0", "c": "10.0.0.192"}, false},
+		// Skipping the ranges already used within the block
+		{"10.0.0.0", "10.0.0.255", [][2]string{{"10.0.0.0", "10.0.0.63"}}, []map[string]interface{}{entry("a", 25), entry("b", 26)}, map[string]string{"a": "10.0.0.128", "b": "10.0.0.64"}, false},
+		// Starting from the first aligned prefix of a block which is not
+		{"10.0.0.64", "10.0.0.255", nil, []map[string]interface{}{entry("a", 25)}, map[string]string{"a": "10.0.0.128"}, false},
+		// No prefix left
+		{"10.0.0.0", "10.0.0.255", [][2]string{{"10.0.0.0", "10.0.0.255"}}, []map[string]interface{}{entry("a", 26)}, nil, true},
+		{"10.0.0.0", "10.0.0.255", nil, []map[string]interface{}{entry("a", 25), entry("b", 25), entry("c", 26)}, nil, true},
+	}
+
+	for i, test := range tests {
+		used := [][2]uint32{}
+
+		for _, r := range test.used {
+			used = append(used, [2]uint32{iptolong(r[0]), iptolong(r[1])})
+		}
+
+		got, err := ipplanallocate(iptolong(test.blockStart), iptolong(test.blockEnd), used, test.entries)
+
+		if (err != nil) != test.fail {
+			t.Errorf("test %d: ipplanallocate error = %v, expected failure: %t", i, err, test.fail)
+			continue
+		}
+
+		for name, address := range test.want {
+			if longtoip(got[name]) != address {
+				t.Errorf("test %d: ipplanallocate allocated %s for IP subnet %s, want %s", i, longtoip(got[name]), name, address)
+			}
+		}
+	}
+}
+
+func TestIP6EUI64Address(t *testing.T) {
+	subnet := func(prefixLength int) map[string]interface{} {
+		return map[string]interface{}{
+			"prefix_length":  prefixLength,
+			"start_hex_addr": "20010db8000000010000000000000000",
+			"end_hex_addr":   "20010db800000001ffffffffffffffff",
+		}
+	}
+
+	tests := []struct {
+		prefixLength int
+		mac          string
+		want         string
+		fail         bool
+	}{
+		{64, "00:11:22:33:44:55", "2001:0db8:0000:0001:0211:22ff:fe33:4455", false},
+		{64, "02-11-22-33-44-55", "2001:0db8:0000:0001:0011:22ff:fe33:4455", false},
+		{56, "00:11:22:33:44:55", "", true},
+		{64, "00:11:22:33:44", "", true},
+		{64, "00:11:22:33:44:55:66:77", "", true},
+	}
+
+	for _, test := range tests {
+		got, err := ip6eui64address(subnet(test.prefixLength), test.mac)
+
+		if (err != nil) != test.fail || got != test.want {
+			t.Errorf("ip6eui64address(/%d, %s) = %s (%v), want %s, expected failure: %t", test.prefixLength, test.mac, got, err, test.want, test.fail)
+		}
+	}
+}
+
+func TestSubnetAddressAtOffset(t *testing.T) {
+	tests := []struct {
+		address      string
+		prefixLength int
+		offset       int
+		v6           bool
+		want         string
+	}{
+		{"10.0.0.0", 24, 1, false, "10.0.0.1"},
+		{"10.0.0.0", 24, -1, false, "10.0.0.254"},
+		{"10.0.1.0", 26, -2, false, "10.0.1.61"},
+		{"10.0.1.64", 26, 10, false, "10.0.1.74"},
+		{"2001:db8::", 64, 1, true, "2001:0db8:0000:0000:0000:0000:0000:0001"},
+		// IPv6 subnets have no broadcast address
+		{"2001:db8::", 64, -1, true, "2001:0db8:0000:0000:ffff:ffff:ffff:ffff"},
+		{"2001:db8:0:100::", 56, -2, true, "2001:0db8:0000:01ff:ffff:ffff:ffff:fffe"},
+	}
+
+	for _, test := range tests {
+		if got := subnetaddressatoffset(test.address, test.prefixLength, test.offset, test.v6); got != test.want {
+			t.Errorf("subnetaddressatoffset(%s, %d, %d, %t) = %s, want %s", test.address, test.prefixLength, test.offset, test.v6, got, test.want)
+		}
+	}
+}
+
+func TestIPPlanReleasing(t *testing.T) {
+	subnetIDs := map[string]string{"a": "1", "b": "2", "c": "3"}
+	prefixes := map[string]string{"a": "10.0.0.0/25", "b": "10.0.0.128/26", "c": "10.0.0.192/26"}
+
+	// b no longer has the prefix length expected by the plan and c is removed from it
+	entries := map[string]map[string]interface{}{
+		"a": {"name": "a", "prefix_size": 25},
+		"b": {"name": "b", "prefix_size": 27},
+	}
+
+	if got := ipplanreleasing(subnetIDs, prefixes, entries); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("ipplanreleasing() = %v, want [b c]", got)
+	}
+}
+
+func TestIPAddressRangeTemplate(t *testing.T) {
+	tests := []struct {
+		first  string
+		second string
+		want   string
+	}{
+		{"web-1", "web-2", "web-%d"},
+		{"srv1-node-1", "srv1-node-2", "srv1-node-%d"},
+		{"1-db", "2-db", "%d-db"},
+		{"rack1-a", "", "rack%d-a"},
+		{"web-1", "db-2", ""},
+		{"web-a", "", ""},
+	}
+
+	for _, test := range tests {
+		if got := ipaddressrangetemplate(test.first, test.second); got != test.want {
+			t.Errorf("ipaddressrangetemplate(%s, %s) = %s, want %s", test.first, test.second, got, test.want)
+		}
+	}
+}